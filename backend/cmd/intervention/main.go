@@ -2,13 +2,17 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/darylhjd/oams/backend/internal/database"
 	"github.com/darylhjd/oams/backend/internal/intervention"
+	"github.com/darylhjd/oams/backend/internal/logger"
+	"github.com/darylhjd/oams/backend/pkg/to"
 )
 
 const (
@@ -19,6 +23,15 @@ const (
 	interventionUrl = "/intervention"
 )
 
+const (
+	// dryRunQueryParam previews the mails of the run without sending them if set to true.
+	dryRunQueryParam = "dry_run"
+	// classIdQueryParam restricts a dry run to the rules of one class.
+	classIdQueryParam = "class_id"
+	// dateQueryParam previews the run at the end of a past date instead of now.
+	dateQueryParam = "date"
+)
+
 func main() {
 	port, ok := os.LookupEnv(functionsCustomHandlerPort)
 	if ok {
@@ -43,6 +56,11 @@ type Response struct {
 func interventionHandler(w http.ResponseWriter, r *http.Request) {
 	now := time.Now()
 
+	if r.URL.Query().Get(dryRunQueryParam) == "true" {
+		dryRunHandler(w, r, now)
+		return
+	}
+
 	service, err := intervention.New(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -54,7 +72,13 @@ func interventionHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	if err = service.Run(r.Context()); err != nil {
+	err = service.Run(r.Context())
+	switch {
+	case errors.Is(err, intervention.ErrRunInProgress):
+		log.Printf("%s - skipping run: %s", intervention.Namespace, err)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -67,3 +91,59 @@ func interventionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// dryRunHandler previews the mails of an intervention run without sending them. No mailer is created, so a dry run does
+// not need the mail configuration.
+func dryRunHandler(w http.ResponseWriter, r *http.Request, now time.Time) {
+	var classId *int64
+	if param := r.URL.Query().Get(classIdQueryParam); param != "" {
+		id, err := to.Int64(param)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s: %s", classIdQueryParam, err), http.StatusUnprocessableEntity)
+			return
+		}
+
+		classId = &id
+	}
+
+	at, err := intervention.PreviewTime(r.URL.Query().Get(dateQueryParam), now)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid %s: %s", dateQueryParam, err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	l, err := logger.NewLogger()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	db, err := database.Connect(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		if err = db.Close(); err != nil {
+			log.Fatalf("%s - could not gracefully close database: %s", intervention.Namespace, err)
+		}
+	}()
+
+	previews, err := intervention.Preview(r.Context(), l, db, intervention.PreviewParams{
+		At:      at,
+		ClassID: classId,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(Response{
+		ReturnValue: previews,
+		Logs:        []string{fmt.Sprintf("Early Intervention Service previewed %d mails", len(previews))},
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
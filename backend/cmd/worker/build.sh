@@ -0,0 +1,9 @@
+#!/bin/bash
+cd "$(dirname "$0")" || exit # Set current directory to where the script is.
+cd ../../
+
+docker buildx build \
+  -t oams-worker \
+  -f cmd/worker/Dockerfile \
+  --secret id=ssh_key,src="$SSH_KEY_SOURCE" \
+  .
\ No newline at end of file
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/darylhjd/oams/backend/internal/scheduler"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s, err := scheduler.New(ctx)
+	if err != nil {
+		log.Fatalf("%s - cannot start service: %s\n", scheduler.Namespace, err)
+	}
+	defer func() {
+		if err = s.Stop(); err != nil {
+			log.Printf("%s - %s", scheduler.Namespace, err)
+		}
+	}()
+
+	if err = s.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		log.Printf("%s - %s", scheduler.Namespace, err)
+	}
+}
@@ -0,0 +1,84 @@
+package checkin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	Namespace = "checkin"
+)
+
+const (
+	// RotationPeriod is how often a new check-in token is issued for a session.
+	RotationPeriod = time.Second * 10
+
+	// validityPeriod is how long a token is accepted for after it is issued. This is slightly longer than the
+	// RotationPeriod to account for the time taken to scan and submit a token.
+	validityPeriod = RotationPeriod * 2
+
+	tokenIssuer = "oams/checkin"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid check-in token")
+)
+
+// Issuer issues and verifies short-lived, signed check-in tokens that are bound to a class group session.
+type Issuer struct {
+	secret []byte
+}
+
+// NewIssuer creates a new Issuer that signs tokens with the given secret.
+func NewIssuer(secret []byte) *Issuer {
+	return &Issuer{secret}
+}
+
+// Issue creates a token for a class group session. Tokens are aligned to the RotationPeriod, so all calls within the
+// same period return the same token. The returned time is when the caller should request a new token.
+func (i *Issuer) Issue(sessionId int64, now time.Time) (string, time.Time, error) {
+	issuedAt := now.Truncate(RotationPeriod)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    tokenIssuer,
+		Subject:   strconv.FormatInt(sessionId, 10),
+		IssuedAt:  jwt.NewNumericDate(issuedAt),
+		NotBefore: jwt.NewNumericDate(issuedAt),
+		ExpiresAt: jwt.NewNumericDate(issuedAt.Add(validityPeriod)),
+	}).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s - could not sign token: %w", Namespace, err)
+	}
+
+	return token, issuedAt.Add(RotationPeriod), nil
+}
+
+// Verify checks that a token is valid at the given time, and returns the class group session that it is bound to.
+func (i *Issuer) Verify(tokenString string, now time.Time) (int64, error) {
+	var claims jwt.RegisteredClaims
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return i.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time {
+			return now
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	sessionId, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad subject", ErrInvalidToken)
+	}
+
+	return sessionId, nil
+}
@@ -0,0 +1,90 @@
+package checkin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuer(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 1, 9, 0, 3, 0, time.UTC)
+
+	tts := []struct {
+		name         string
+		verifySecret string
+		verifyAt     time.Time
+		wantErr      bool
+	}{
+		{
+			"verify immediately",
+			"secret",
+			issuedAt,
+			false,
+		},
+		{
+			"verify after token rotated",
+			"secret",
+			issuedAt.Add(RotationPeriod),
+			false,
+		},
+		{
+			"verify after token expired",
+			"secret",
+			issuedAt.Add(validityPeriod),
+			true,
+		},
+		{
+			"verify before token issued",
+			"secret",
+			issuedAt.Add(-RotationPeriod),
+			true,
+		},
+		{
+			"verify with different secret",
+			"other secret",
+			issuedAt,
+			true,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			token, refreshAt, err := NewIssuer([]byte("secret")).Issue(1, issuedAt)
+			a.Nil(err)
+			a.Equal(issuedAt.Truncate(RotationPeriod).Add(RotationPeriod), refreshAt)
+
+			sessionId, err := NewIssuer([]byte(tt.verifySecret)).Verify(token, tt.verifyAt)
+			if tt.wantErr {
+				a.ErrorIs(err, ErrInvalidToken)
+				return
+			}
+
+			a.Nil(err)
+			a.Equal(int64(1), sessionId)
+		})
+	}
+}
+
+func TestIssuer_Rotation(t *testing.T) {
+	a := assert.New(t)
+	issuer := NewIssuer([]byte("secret"))
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	first, _, err := issuer.Issue(1, start)
+	a.Nil(err)
+
+	same, _, err := issuer.Issue(1, start.Add(RotationPeriod-time.Second))
+	a.Nil(err)
+	a.Equal(first, same)
+
+	rotated, _, err := issuer.Issue(1, start.Add(RotationPeriod))
+	a.Nil(err)
+	a.NotEqual(first, rotated)
+
+	other, _, err := issuer.Issue(2, start)
+	a.Nil(err)
+	a.NotEqual(first, other)
+}
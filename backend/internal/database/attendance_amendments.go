@@ -0,0 +1,266 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/enum"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// AttendanceAmendment contains information on a proposed change to the attendance of a closed session.
+type AttendanceAmendment struct {
+	ID                  int64                  `alias:"attendance_amendment.id" json:"id"`
+	SessionEnrollmentID int64                  `alias:"attendance_amendment.session_enrollment_id" json:"session_enrollment_id"`
+	SessionID           int64                  `alias:"session_enrollment.session_id" json:"session_id"`
+	UserID              string                 `alias:"session_enrollment.user_id" json:"user_id"`
+	UserName            string                 `alias:"user.name" json:"user_name"`
+	ClassID             int64                  `alias:"class.id" json:"class_id"`
+	ClassCode           string                 `alias:"class.code" json:"class_code"`
+	ClassGroupName      string                 `alias:"class_group.name" json:"class_group_name"`
+	ClassType           model.ClassType        `alias:"class_group.class_type" json:"class_type"`
+	StartTime           time.Time              `alias:"class_group_session.start_time" json:"start_time"`
+	EndTime             time.Time              `alias:"class_group_session.end_time" json:"end_time"`
+	RequesterID         string                 `alias:"attendance_amendment.requester_id" json:"requester_id"`
+	PreviousStatus      model.AttendanceStatus `alias:"attendance_amendment.previous_status" json:"previous_status"`
+	ProposedStatus      model.AttendanceStatus `alias:"attendance_amendment.proposed_status" json:"proposed_status"`
+	Reason              string                 `alias:"attendance_amendment.reason" json:"reason"`
+	Status              model.AmendmentStatus  `alias:"attendance_amendment.status" json:"status"`
+	ReviewerID          *string                `alias:"attendance_amendment.reviewer_id" json:"reviewer_id"`
+	ReviewComment       string                 `alias:"attendance_amendment.review_comment" json:"review_comment"`
+	ReviewedAt          *time.Time             `alias:"attendance_amendment.reviewed_at" json:"reviewed_at"`
+	CreatedAt           time.Time              `alias:"attendance_amendment.created_at" json:"created_at"`
+}
+
+func (d *DB) GetAttendanceAmendments(ctx context.Context) ([]AttendanceAmendment, error) {
+	var res []AttendanceAmendment
+
+	stmt := selectAttendanceAmendmentFields().WHERE(
+		attendanceAmendmentRLS(ctx),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type CreateAttendanceAmendmentParams struct {
+	SessionEnrollmentID int64                  `json:"session_enrollment_id"`
+	ProposedStatus      model.AttendanceStatus `json:"proposed_status"`
+	Reason              string                 `json:"reason"`
+}
+
+// CreateAttendanceAmendment proposes a change to the attendance of a session enrollment. The session must be managed
+// by the current user, must have ended, and attendance for the class must not be locked.
+func (d *DB) CreateAttendanceAmendment(ctx context.Context, arg CreateAttendanceAmendmentParams) (AttendanceAmendment, error) {
+	var res AttendanceAmendment
+
+	var amendment model.AttendanceAmendment
+
+	stmt := AttendanceAmendments.INSERT(
+		AttendanceAmendments.SessionEnrollmentID,
+		AttendanceAmendments.RequesterID,
+		AttendanceAmendments.PreviousStatus,
+		AttendanceAmendments.ProposedStatus,
+		AttendanceAmendments.Reason,
+	).QUERY(
+		SELECT(
+			SessionEnrollments.ID,
+			String(oauth2.GetAuthContext(ctx).User.ID),
+			SessionEnrollments.Status,
+			attendanceStatus(arg.ProposedStatus),
+			String(arg.Reason),
+		).FROM(
+			SessionEnrollments.INNER_JOIN(
+				ClassGroupSessions, ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID),
+			).INNER_JOIN(
+				ClassGroups, ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
+			).INNER_JOIN(
+				Classes, Classes.ID.EQ(ClassGroups.ClassID),
+			),
+		).WHERE(
+			managedClassGroupSessionRLS(ctx).AND(
+				SessionEnrollments.ID.EQ(Int64(arg.SessionEnrollmentID)),
+			).AND(
+				isClosedClassGroupSession(),
+			).AND(
+				isAttendanceUnlocked(),
+			),
+		),
+	).RETURNING(
+		AttendanceAmendments.ID,
+	)
+
+	if err := stmt.QueryContext(ctx, d.qe, &amendment); err != nil {
+		return res, err
+	}
+
+	selectStmt := selectAttendanceAmendmentFields().WHERE(
+		AttendanceAmendments.ID.EQ(Int64(amendment.ID)),
+	)
+
+	err := selectStmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func (d *DB) GetCoordinatingClassAttendanceAmendments(ctx context.Context, classId int64) ([]AttendanceAmendment, error) {
+	var res []AttendanceAmendment
+
+	stmt := selectAttendanceAmendmentFields().WHERE(
+		coordinatingClassRLS(ctx).AND(
+			Classes.ID.EQ(Int64(classId)),
+		),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type ReviewCoordinatingClassAttendanceAmendmentParams struct {
+	ClassID       int64
+	AmendmentID   int64
+	Status        model.AmendmentStatus
+	ReviewComment string
+}
+
+// ReviewCoordinatingClassAttendanceAmendment approves or rejects a pending attendance amendment. If the amendment is
+// approved, the proposed status is applied to the session enrollment. Attendance for the class must not be locked.
+func (d *DB) ReviewCoordinatingClassAttendanceAmendment(ctx context.Context, arg ReviewCoordinatingClassAttendanceAmendmentParams) (AttendanceAmendment, error) {
+	var res AttendanceAmendment
+
+	reviewerId := oauth2.GetAuthContext(ctx).User.ID
+
+	var amendment model.AttendanceAmendment
+
+	stmt := AttendanceAmendments.UPDATE(
+		AttendanceAmendments.Status,
+		AttendanceAmendments.ReviewerID,
+		AttendanceAmendments.ReviewComment,
+		AttendanceAmendments.ReviewedAt,
+	).MODEL(
+		model.AttendanceAmendment{
+			Status:        arg.Status,
+			ReviewerID:    &reviewerId,
+			ReviewComment: arg.ReviewComment,
+			ReviewedAt:    to.Ptr(time.Now()),
+		},
+	).WHERE(
+		AttendanceAmendments.SessionEnrollmentID.IN(
+			SELECT(
+				SessionEnrollments.ID,
+			).FROM(
+				SessionEnrollments.INNER_JOIN(
+					ClassGroupSessions, ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID),
+				).INNER_JOIN(
+					ClassGroups, ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
+				).INNER_JOIN(
+					Classes, Classes.ID.EQ(ClassGroups.ClassID),
+				),
+			).WHERE(
+				coordinatingClassRLS(ctx).AND(
+					Classes.ID.EQ(Int64(arg.ClassID)),
+				).AND(
+					isAttendanceUnlocked(),
+				),
+			),
+		).AND(
+			AttendanceAmendments.ID.EQ(Int64(arg.AmendmentID)),
+		).AND(
+			AttendanceAmendments.Status.EQ(AmendmentStatus.Pending),
+		),
+	).RETURNING(
+		AttendanceAmendments.AllColumns,
+	)
+
+	if err := stmt.QueryContext(ctx, d.qe, &amendment); err != nil {
+		return res, err
+	}
+
+	if amendment.Status == model.AmendmentStatus_Approved {
+		status := attendanceStatus(amendment.ProposedStatus)
+		enrollmentStmt := SessionEnrollments.UPDATE(
+			SessionEnrollments.Status,
+			SessionEnrollments.MarkedBy,
+			SessionEnrollments.CheckedInAt,
+			SessionEnrollments.Source,
+			SessionEnrollments.ReaderID,
+		).SET(
+			status,
+			String(reviewerId),
+			checkedInAt(status, ClassGroupSessions.StartTime),
+			AttendanceSource.Manual,
+			StringExp(NULL),
+		).FROM(
+			ClassGroupSessions,
+		).WHERE(
+			ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID).AND(
+				SessionEnrollments.ID.EQ(Int64(amendment.SessionEnrollmentID)),
+			),
+		)
+
+		if _, err := enrollmentStmt.ExecContext(ctx, d.qe); err != nil {
+			return res, err
+		}
+	}
+
+	selectStmt := selectAttendanceAmendmentFields().WHERE(
+		AttendanceAmendments.ID.EQ(Int64(amendment.ID)),
+	)
+
+	err := selectStmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func selectAttendanceAmendmentFields() SelectStatement {
+	return SELECT(
+		AttendanceAmendments.ID,
+		AttendanceAmendments.SessionEnrollmentID,
+		SessionEnrollments.SessionID,
+		SessionEnrollments.UserID,
+		Users.Name,
+		Classes.ID,
+		Classes.Code,
+		ClassGroups.Name,
+		ClassGroups.ClassType,
+		ClassGroupSessions.StartTime,
+		ClassGroupSessions.EndTime,
+		AttendanceAmendments.RequesterID,
+		AttendanceAmendments.PreviousStatus,
+		AttendanceAmendments.ProposedStatus,
+		AttendanceAmendments.Reason,
+		AttendanceAmendments.Status,
+		AttendanceAmendments.ReviewerID,
+		AttendanceAmendments.ReviewComment,
+		AttendanceAmendments.ReviewedAt,
+		AttendanceAmendments.CreatedAt,
+	).FROM(
+		AttendanceAmendments.INNER_JOIN(
+			SessionEnrollments, SessionEnrollments.ID.EQ(AttendanceAmendments.SessionEnrollmentID),
+		).INNER_JOIN(
+			Users, Users.ID.EQ(SessionEnrollments.UserID),
+		).INNER_JOIN(
+			ClassGroupSessions, ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID),
+		).INNER_JOIN(
+			ClassGroups, ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
+		).INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassGroups.ClassID),
+		),
+	).ORDER_BY(
+		AttendanceAmendments.CreatedAt.DESC(),
+	)
+}
+
+// isClosedClassGroupSession checks that a class group session has ended and is no longer open for attendance taking.
+func isClosedClassGroupSession() BoolExpression {
+	return attendanceClosesAt().LT(TimestampzT(time.Now()))
+}
+
+// isAttendanceUnlocked checks that the attendance of a class can still be amended. The Classes table must be in scope.
+func isAttendanceUnlocked() BoolExpression {
+	return Classes.AttendanceLockedAfter.IS_NULL().OR(
+		Classes.AttendanceLockedAfter.GT(TimestampzT(time.Now())),
+	)
+}
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/enum"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+const (
+	// maxCardTapClockSkew is how far ahead of the server clock a reader may timestamp a tap.
+	maxCardTapClockSkew = time.Minute
+	// maxCardTapAge is how far behind the server clock a reader may timestamp a tap. Older taps are not recorded, so
+	// that attendance of past sessions can only be changed through amendments.
+	maxCardTapAge = time.Hour
+)
+
+// CardTapOutcome describes what happened to a tap from a card reader.
+type CardTapOutcome string
+
+const (
+	CardTapOutcomeRecorded        CardTapOutcome = "RECORDED"
+	CardTapOutcomeAlreadyRecorded CardTapOutcome = "ALREADY_RECORDED"
+	CardTapOutcomeUnknownCard     CardTapOutcome = "UNKNOWN_CARD"
+	CardTapOutcomeOutOfWindow     CardTapOutcome = "OUT_OF_WINDOW"
+)
+
+type RecordCardTapParams struct {
+	ReaderID  string    `json:"reader_id"`
+	Venue     string    `json:"venue"`
+	CardUID   string    `json:"card_uid"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type CardTapResult struct {
+	RecordCardTapParams
+	Outcome             CardTapOutcome          `json:"outcome"`
+	SessionEnrollmentID *int64                  `json:"session_enrollment_id"`
+	Status              *model.AttendanceStatus `json:"status"`
+}
+
+// RecordCardTaps records the attendance of each tap from a card reader. A tap is resolved to its user by the card UID,
+// and then to the session enrollment of that user whose class group session is at the venue of the tap and open for
+// attendance taking at the time of the tap. The reader of the tap is kept on the session enrollment. Taps that cannot
+// be resolved, are too old, or are for a class whose attendance is locked are reported in the outcome of their result.
+func (d *DB) RecordCardTaps(ctx context.Context, args []RecordCardTapParams) ([]CardTapResult, error) {
+	res := make([]CardTapResult, 0, len(args))
+
+	cards, err := d.getUserCards(ctx, args)
+	if err != nil {
+		return res, err
+	}
+
+	now := time.Now()
+	for _, arg := range args {
+		result := CardTapResult{RecordCardTapParams: arg}
+
+		userId, ok := cards[arg.CardUID]
+		switch {
+		case !ok:
+			result.Outcome = CardTapOutcomeUnknownCard
+		case arg.Timestamp.After(now.Add(maxCardTapClockSkew)), arg.Timestamp.Before(now.Add(-maxCardTapAge)):
+			result.Outcome = CardTapOutcomeOutOfWindow
+		default:
+			if result, err = d.recordCardTap(ctx, userId, result); err != nil {
+				return res, err
+			}
+		}
+
+		res = append(res, result)
+	}
+
+	return res, nil
+}
+
+// getUserCards returns a mapping of card UID to user ID for the cards in the given taps.
+func (d *DB) getUserCards(ctx context.Context, args []RecordCardTapParams) (map[string]string, error) {
+	cards := map[string]string{}
+	if len(args) == 0 {
+		return cards, nil
+	}
+
+	uids := make([]Expression, 0, len(args))
+	for _, arg := range args {
+		uids = append(uids, String(arg.CardUID))
+	}
+
+	var userCards []model.UserCard
+
+	stmt := SELECT(
+		UserCards.AllColumns,
+	).FROM(
+		UserCards,
+	).WHERE(
+		UserCards.CardUID.IN(uids...),
+	)
+
+	if err := stmt.QueryContext(ctx, d.qe, &userCards); err != nil {
+		return cards, err
+	}
+
+	for _, card := range userCards {
+		cards[card.CardUID] = card.UserID
+	}
+
+	return cards, nil
+}
+
+func (d *DB) recordCardTap(ctx context.Context, userId string, result CardTapResult) (CardTapResult, error) {
+	var enrollment model.SessionEnrollment
+
+	enrollmentStmt := SELECT(
+		SessionEnrollments.AllColumns,
+	).FROM(
+		SessionEnrollments.INNER_JOIN(
+			ClassGroupSessions, ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID),
+		).INNER_JOIN(
+			ClassGroups, ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
+		).INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassGroups.ClassID),
+		),
+	).WHERE(
+		sessionEnrollmentRLS(ctx).AND(
+			SessionEnrollments.UserID.EQ(String(userId)),
+		).AND(
+			ClassGroupSessions.Venue.EQ(String(result.Venue)),
+		).AND(
+			isOpenClassGroupSessionAt(result.Timestamp),
+		).AND(
+			isAttendanceUnlocked(),
+		),
+	).ORDER_BY(
+		ClassGroupSessions.StartTime,
+	).LIMIT(1)
+
+	err := enrollmentStmt.QueryContext(ctx, d.qe, &enrollment)
+	switch {
+	case errors.Is(err, qrm.ErrNoRows):
+		result.Outcome = CardTapOutcomeOutOfWindow
+		return result, nil
+	case err != nil:
+		return result, err
+	}
+
+	result.SessionEnrollmentID = &enrollment.ID
+	if enrollment.Status == model.AttendanceStatus_Present || enrollment.Status == model.AttendanceStatus_Late {
+		result.Outcome = CardTapOutcomeAlreadyRecorded
+		result.Status = &enrollment.Status
+		return result, nil
+	}
+
+	status := checkInStatus(TimestampzT(result.Timestamp))
+	stmt := SessionEnrollments.UPDATE(
+		SessionEnrollments.Status,
+		SessionEnrollments.MarkedBy,
+		SessionEnrollments.CheckedInAt,
+		SessionEnrollments.Source,
+		SessionEnrollments.ReaderID,
+	).SET(
+		status,
+		String(oauth2.GetAuthContext(ctx).User.ID),
+		checkedInAt(status, TimestampzT(result.Timestamp)),
+		AttendanceSource.ExternalService,
+		String(result.ReaderID),
+	).FROM(
+		ClassGroupSessions.INNER_JOIN(
+			ClassGroups, ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
+		).INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassGroups.ClassID),
+		),
+	).WHERE(
+		ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID).AND(
+			SessionEnrollments.ID.EQ(Int64(enrollment.ID)),
+		).AND(
+			isAttendanceUnlocked(),
+		),
+	).RETURNING(
+		SessionEnrollments.AllColumns,
+	)
+
+	err = stmt.QueryContext(ctx, d.qe, &enrollment)
+	switch {
+	case errors.Is(err, qrm.ErrNoRows):
+		result.Outcome = CardTapOutcomeOutOfWindow
+		result.SessionEnrollmentID = nil
+		return result, nil
+	case err != nil:
+		return result, err
+	}
+
+	result.Outcome = CardTapOutcomeRecorded
+	result.Status = &enrollment.Status
+	return result, nil
+}
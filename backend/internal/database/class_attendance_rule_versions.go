@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// GetCoordinatingClassRuleVersions gets the versions of a rule of a class, from the latest version.
+func (d *DB) GetCoordinatingClassRuleVersions(ctx context.Context, classId, ruleId int64) ([]model.ClassAttendanceRuleVersion, error) {
+	var res []model.ClassAttendanceRuleVersion
+
+	stmt := SELECT(
+		ClassAttendanceRuleVersions.AllColumns,
+	).FROM(
+		ClassAttendanceRuleVersions.INNER_JOIN(
+			ClassAttendanceRules, ClassAttendanceRules.ID.EQ(ClassAttendanceRuleVersions.RuleID),
+		).INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassAttendanceRules.ClassID),
+		),
+	).WHERE(
+		coordinatingClassRLS(ctx).AND(
+			Classes.ID.EQ(Int64(classId)),
+		).AND(
+			ClassAttendanceRules.ID.EQ(Int64(ruleId)),
+		),
+	).ORDER_BY(
+		ClassAttendanceRuleVersions.Version.DESC(),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// createRuleVersions records the current definition of each rule as a new version.
+func (d *DB) createRuleVersions(ctx context.Context, editorId string, rules ...model.ClassAttendanceRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	stmt := ClassAttendanceRuleVersions.INSERT(
+		ClassAttendanceRuleVersions.RuleID,
+		ClassAttendanceRuleVersions.Version,
+		ClassAttendanceRuleVersions.EditorID,
+		ClassAttendanceRuleVersions.Title,
+		ClassAttendanceRuleVersions.Description,
+		ClassAttendanceRuleVersions.Rule,
+		ClassAttendanceRuleVersions.Environment,
+	)
+
+	for _, rule := range rules {
+		stmt = stmt.MODEL(
+			model.ClassAttendanceRuleVersion{
+				RuleID:      rule.ID,
+				Version:     rule.Version,
+				EditorID:    editorId,
+				Title:       rule.Title,
+				Description: rule.Description,
+				Rule:        rule.Rule,
+				Environment: rule.Environment,
+			},
+		)
+	}
+
+	_, err := stmt.ExecContext(ctx, d.qe)
+	return err
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// CoordinatingClassNotificationTemplate contains the notification template overrides of a class. A nil template means
+// the built-in template is used.
+type CoordinatingClassNotificationTemplate struct {
+	ClassID                 int64      `alias:"class.id" json:"class_id"`
+	EditorID                *string    `alias:"class_notification_template.editor_id" json:"editor_id"`
+	UserTextTemplate        *string    `alias:"class_notification_template.user_text_template" json:"user_text_template"`
+	UserHtmlTemplate        *string    `alias:"class_notification_template.user_html_template" json:"user_html_template"`
+	RuleCreatorTextTemplate *string    `alias:"class_notification_template.rule_creator_text_template" json:"rule_creator_text_template"`
+	RuleCreatorHtmlTemplate *string    `alias:"class_notification_template.rule_creator_html_template" json:"rule_creator_html_template"`
+	UpdatedAt               *time.Time `alias:"class_notification_template.updated_at" json:"updated_at"`
+}
+
+// GetCoordinatingClassNotificationTemplate gets the notification template overrides of a class. The templates are nil
+// if the class has no overrides.
+func (d *DB) GetCoordinatingClassNotificationTemplate(ctx context.Context, classId int64) (CoordinatingClassNotificationTemplate, error) {
+	var res CoordinatingClassNotificationTemplate
+
+	stmt := SELECT(
+		Classes.ID,
+		ClassNotificationTemplates.EditorID,
+		ClassNotificationTemplates.UserTextTemplate,
+		ClassNotificationTemplates.UserHTMLTemplate,
+		ClassNotificationTemplates.RuleCreatorTextTemplate,
+		ClassNotificationTemplates.RuleCreatorHTMLTemplate,
+		ClassNotificationTemplates.UpdatedAt,
+	).FROM(
+		Classes.LEFT_JOIN(
+			ClassNotificationTemplates, ClassNotificationTemplates.ClassID.EQ(Classes.ID),
+		),
+	).WHERE(
+		coordinatingClassRLS(ctx).AND(
+			Classes.ID.EQ(Int64(classId)),
+		),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type UpsertCoordinatingClassNotificationTemplateParams struct {
+	ClassID                 int64
+	EditorID                string
+	UserTextTemplate        *string
+	UserHtmlTemplate        *string
+	RuleCreatorTextTemplate *string
+	RuleCreatorHtmlTemplate *string
+}
+
+// UpsertCoordinatingClassNotificationTemplate sets the notification template overrides of a class, replacing any
+// existing overrides.
+func (d *DB) UpsertCoordinatingClassNotificationTemplate(ctx context.Context, arg UpsertCoordinatingClassNotificationTemplateParams) (model.ClassNotificationTemplate, error) {
+	var res model.ClassNotificationTemplate
+
+	nullableString := func(s *string) StringExpression {
+		if s == nil {
+			return StringExp(NULL)
+		}
+
+		return String(*s)
+	}
+
+	stmt := ClassNotificationTemplates.INSERT(
+		ClassNotificationTemplates.ClassID,
+		ClassNotificationTemplates.EditorID,
+		ClassNotificationTemplates.UserTextTemplate,
+		ClassNotificationTemplates.UserHTMLTemplate,
+		ClassNotificationTemplates.RuleCreatorTextTemplate,
+		ClassNotificationTemplates.RuleCreatorHTMLTemplate,
+	).QUERY(
+		SELECT(
+			Int64(arg.ClassID),
+			String(arg.EditorID),
+			nullableString(arg.UserTextTemplate),
+			nullableString(arg.UserHtmlTemplate),
+			nullableString(arg.RuleCreatorTextTemplate),
+			nullableString(arg.RuleCreatorHtmlTemplate),
+		).WHERE(
+			EXISTS(
+				SELECT(
+					Classes.AllColumns,
+				).FROM(
+					Classes,
+				).WHERE(
+					coordinatingClassRLS(ctx).AND(
+						Classes.ID.EQ(Int64(arg.ClassID)),
+					),
+				),
+			),
+		),
+	).ON_CONFLICT(
+		ClassNotificationTemplates.ClassID,
+	).DO_UPDATE(
+		SET(
+			ClassNotificationTemplates.EditorID.SET(ClassNotificationTemplates.EXCLUDED.EditorID),
+			ClassNotificationTemplates.UserTextTemplate.SET(ClassNotificationTemplates.EXCLUDED.UserTextTemplate),
+			ClassNotificationTemplates.UserHTMLTemplate.SET(ClassNotificationTemplates.EXCLUDED.UserHTMLTemplate),
+			ClassNotificationTemplates.RuleCreatorTextTemplate.SET(ClassNotificationTemplates.EXCLUDED.RuleCreatorTextTemplate),
+			ClassNotificationTemplates.RuleCreatorHTMLTemplate.SET(ClassNotificationTemplates.EXCLUDED.RuleCreatorHTMLTemplate),
+		),
+	).RETURNING(
+		ClassNotificationTemplates.AllColumns,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// DeleteCoordinatingClassNotificationTemplate removes the notification template overrides of a class, so that the
+// built-in templates are used.
+func (d *DB) DeleteCoordinatingClassNotificationTemplate(ctx context.Context, classId int64) error {
+	var res model.ClassNotificationTemplate
+
+	stmt := ClassNotificationTemplates.DELETE().
+		WHERE(
+			EXISTS(
+				SELECT(
+					Classes.AllColumns,
+				).FROM(
+					Classes,
+				).WHERE(
+					coordinatingClassRLS(ctx).AND(
+						Classes.ID.EQ(Int64(classId)),
+					),
+				),
+			).AND(
+				ClassNotificationTemplates.ClassID.EQ(Int64(classId)),
+			),
+		).RETURNING(
+		ClassNotificationTemplates.AllColumns,
+	)
+
+	return stmt.QueryContext(ctx, d.qe, &res)
+}
+
+// GetClassNotificationTemplates gets the notification template overrides of the given classes. Classes without
+// overrides are not returned.
+func (d *DB) GetClassNotificationTemplates(ctx context.Context, classIds []int64) ([]model.ClassNotificationTemplate, error) {
+	var res []model.ClassNotificationTemplate
+
+	if len(classIds) == 0 {
+		return res, nil
+	}
+
+	ids := make([]Expression, 0, len(classIds))
+	for _, id := range classIds {
+		ids = append(ids, Int64(id))
+	}
+
+	stmt := SELECT(
+		ClassNotificationTemplates.AllColumns,
+	).FROM(
+		ClassNotificationTemplates,
+	).WHERE(
+		ClassNotificationTemplates.ClassID.IN(ids...),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
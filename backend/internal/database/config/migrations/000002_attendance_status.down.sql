@@ -0,0 +1,25 @@
+BEGIN;
+
+ALTER TABLE session_enrollments
+    ADD COLUMN attended BOOLEAN;
+
+ALTER TABLE session_enrollments
+    DISABLE TRIGGER update_updated_at;
+
+UPDATE session_enrollments
+SET attended = status IN ('PRESENT', 'LATE');
+
+ALTER TABLE session_enrollments
+    ENABLE TRIGGER update_updated_at;
+
+ALTER TABLE session_enrollments
+    ALTER COLUMN attended SET NOT NULL,
+    DROP COLUMN status;
+
+ALTER TABLE classes
+    DROP CONSTRAINT ck_late_threshold_minutes_non_negative,
+    DROP COLUMN late_threshold_minutes;
+
+DROP TYPE ATTENDANCE_STATUS;
+
+COMMIT;
@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TYPE ATTENDANCE_STATUS AS ENUM ('PRESENT', 'LATE', 'ABSENT', 'EXCUSED');
+
+ALTER TABLE classes
+    ADD COLUMN late_threshold_minutes INTEGER NOT NULL DEFAULT 15,
+    ADD CONSTRAINT ck_late_threshold_minutes_non_negative
+        CHECK (late_threshold_minutes >= 0);
+
+ALTER TABLE session_enrollments
+    ADD COLUMN status ATTENDANCE_STATUS;
+
+-- Avoid bumping updated_at for every enrollment while moving existing rows over.
+ALTER TABLE session_enrollments
+    DISABLE TRIGGER update_updated_at;
+
+UPDATE session_enrollments
+SET status = CASE WHEN attended THEN 'PRESENT'::ATTENDANCE_STATUS ELSE 'ABSENT'::ATTENDANCE_STATUS END;
+
+ALTER TABLE session_enrollments
+    ENABLE TRIGGER update_updated_at;
+
+ALTER TABLE session_enrollments
+    ALTER COLUMN status SET NOT NULL,
+    DROP COLUMN attended;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE session_enrollments
+    DROP CONSTRAINT fk_marked_by,
+    DROP COLUMN marked_by,
+    DROP COLUMN checked_in_at,
+    DROP COLUMN source;
+
+DROP TYPE ATTENDANCE_SOURCE;
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TYPE ATTENDANCE_SOURCE AS ENUM ('MANUAL', 'SIGNATURE', 'EXTERNAL_SERVICE', 'IMPORT', 'SELF_CHECK_IN');
+
+-- Enrollments that existed before this migration are recorded as imported, since the channel used to mark them is not
+-- known.
+ALTER TABLE session_enrollments
+    ADD COLUMN marked_by     TEXT,
+    ADD COLUMN checked_in_at TIMESTAMPTZ,
+    ADD COLUMN source        ATTENDANCE_SOURCE NOT NULL DEFAULT 'IMPORT',
+    ADD CONSTRAINT fk_marked_by
+        FOREIGN KEY (marked_by)
+            REFERENCES users (id);
+
+-- The last update is the best estimate of when an attended student checked in.
+ALTER TABLE session_enrollments
+    DISABLE TRIGGER update_updated_at;
+
+UPDATE session_enrollments
+SET checked_in_at = updated_at
+WHERE status IN ('PRESENT', 'LATE');
+
+ALTER TABLE session_enrollments
+    ENABLE TRIGGER update_updated_at;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE excusal_session_enrollments;
+
+DROP TABLE excusals;
+
+DROP TYPE EXCUSAL_STATUS;
+
+COMMIT;
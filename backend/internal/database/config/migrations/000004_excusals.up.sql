@@ -0,0 +1,53 @@
+BEGIN;
+
+CREATE TYPE EXCUSAL_STATUS AS ENUM ('PENDING', 'APPROVED', 'REJECTED');
+
+CREATE TABLE excusals
+(
+    id             BIGSERIAL PRIMARY KEY,
+    class_id       BIGINT         NOT NULL,
+    user_id        TEXT           NOT NULL,
+    reason         TEXT           NOT NULL,
+    document_name  TEXT           NOT NULL,
+    document_type  TEXT           NOT NULL,
+    document       BYTEA          NOT NULL,
+    status         EXCUSAL_STATUS NOT NULL DEFAULT 'PENDING',
+    reviewer_id    TEXT,
+    review_comment TEXT           NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_class_id
+        FOREIGN KEY (class_id)
+            REFERENCES classes (id),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id),
+    CONSTRAINT fk_reviewer_id
+        FOREIGN KEY (reviewer_id)
+            REFERENCES users (id)
+);
+
+CREATE TRIGGER update_updated_at
+    BEFORE UPDATE
+    ON excusals
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at();
+
+CREATE TABLE excusal_session_enrollments
+(
+    id                    BIGSERIAL PRIMARY KEY,
+    excusal_id            BIGINT      NOT NULL,
+    session_enrollment_id BIGINT      NOT NULL,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ux_excusal_id_session_enrollment_id
+        UNIQUE (excusal_id, session_enrollment_id),
+    CONSTRAINT fk_excusal_id
+        FOREIGN KEY (excusal_id)
+            REFERENCES excusals (id),
+    CONSTRAINT fk_session_enrollment_id
+        FOREIGN KEY (session_enrollment_id)
+            REFERENCES session_enrollments (id)
+);
+
+COMMIT;
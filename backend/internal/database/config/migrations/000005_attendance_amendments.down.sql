@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE attendance_amendments;
+
+ALTER TABLE classes
+    DROP COLUMN attendance_locked_after;
+
+DROP TYPE AMENDMENT_STATUS;
+
+COMMIT;
@@ -0,0 +1,45 @@
+BEGIN;
+
+CREATE TYPE AMENDMENT_STATUS AS ENUM ('PENDING', 'APPROVED', 'REJECTED');
+
+-- No amendments can be made to a class after this time. NULL means attendance is never locked.
+ALTER TABLE classes
+    ADD COLUMN attendance_locked_after TIMESTAMPTZ;
+
+CREATE TABLE attendance_amendments
+(
+    id                    BIGSERIAL PRIMARY KEY,
+    session_enrollment_id BIGINT            NOT NULL,
+    requester_id          TEXT              NOT NULL,
+    previous_status       ATTENDANCE_STATUS NOT NULL,
+    proposed_status       ATTENDANCE_STATUS NOT NULL,
+    reason                TEXT              NOT NULL,
+    status                AMENDMENT_STATUS  NOT NULL DEFAULT 'PENDING',
+    reviewer_id           TEXT,
+    review_comment        TEXT              NOT NULL DEFAULT '',
+    reviewed_at           TIMESTAMPTZ,
+    created_at            TIMESTAMPTZ       NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMPTZ       NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_session_enrollment_id
+        FOREIGN KEY (session_enrollment_id)
+            REFERENCES session_enrollments (id),
+    CONSTRAINT fk_requester_id
+        FOREIGN KEY (requester_id)
+            REFERENCES users (id),
+    CONSTRAINT fk_reviewer_id
+        FOREIGN KEY (reviewer_id)
+            REFERENCES users (id)
+);
+
+-- Only one amendment may be pending for each session enrollment at any time.
+CREATE UNIQUE INDEX ux_session_enrollment_id_pending
+    ON attendance_amendments (session_enrollment_id)
+    WHERE status = 'PENDING';
+
+CREATE TRIGGER update_updated_at
+    BEFORE UPDATE
+    ON attendance_amendments
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at();
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TRIGGER notify_session_enrollment_change ON session_enrollments;
+
+DROP FUNCTION notify_session_enrollment_change;
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+-- Publishes changes to the attendance of a session enrollment so that connected clients can be kept in sync. The
+-- payload matches the attendance entry returned by the API.
+CREATE FUNCTION notify_session_enrollment_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    PERFORM pg_notify('session_enrollment_changes', JSON_BUILD_OBJECT(
+            'id', NEW.id,
+            'session_id', NEW.session_id,
+            'user_id', NEW.user_id,
+            'user_name', (SELECT name FROM users WHERE id = NEW.user_id),
+            'status', NEW.status,
+            'marked_by', NEW.marked_by,
+            'checked_in_at', NEW.checked_in_at,
+            'source', NEW.source
+        )::TEXT);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_session_enrollment_change
+    AFTER UPDATE
+    ON session_enrollments
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status
+        OR OLD.marked_by IS DISTINCT FROM NEW.marked_by
+        OR OLD.checked_in_at IS DISTINCT FROM NEW.checked_in_at
+        OR OLD.source IS DISTINCT FROM NEW.source)
+EXECUTE PROCEDURE notify_session_enrollment_change();
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP TABLE signature_lockouts;
+
+ALTER TABLE user_signatures
+    DROP COLUMN locked_until,
+    DROP COLUMN lockouts,
+    DROP COLUMN failed_attempts;
+
+ALTER TABLE session_enrollments
+    DROP COLUMN signature_failed_attempts;
+
+COMMIT;
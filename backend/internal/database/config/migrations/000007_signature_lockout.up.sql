@@ -0,0 +1,33 @@
+BEGIN;
+
+-- Failed signature attempts since the last successful attempt, tracked for each session enrollment.
+ALTER TABLE session_enrollments
+    ADD COLUMN signature_failed_attempts INTEGER NOT NULL DEFAULT 0;
+
+-- Failed signature attempts and lockout state, tracked for each user. Lockouts counts the consecutive lockouts since
+-- the last successful attempt and is used to back off the lockout duration.
+ALTER TABLE user_signatures
+    ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN lockouts        INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN locked_until    TIMESTAMPTZ;
+
+CREATE TABLE signature_lockouts
+(
+    id                    BIGSERIAL PRIMARY KEY,
+    user_id               TEXT        NOT NULL,
+    session_enrollment_id BIGINT      NOT NULL,
+    triggered_by          TEXT        NOT NULL,
+    locked_until          TIMESTAMPTZ NOT NULL,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id),
+    CONSTRAINT fk_session_enrollment_id
+        FOREIGN KEY (session_enrollment_id)
+            REFERENCES session_enrollments (id),
+    CONSTRAINT fk_triggered_by
+        FOREIGN KEY (triggered_by)
+            REFERENCES users (id)
+);
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+-- Maps the UID of a card or barcode presented at a reader to its user.
+CREATE TABLE user_cards
+(
+    id         BIGSERIAL PRIMARY KEY,
+    card_uid   TEXT        NOT NULL,
+    user_id    TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ux_card_uid
+        UNIQUE (card_uid),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+);
+
+CREATE TRIGGER update_updated_at
+    BEFORE UPDATE
+    ON user_cards
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at();
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE class_groups
+    DROP COLUMN attendance_closes_after_minutes,
+    DROP COLUMN attendance_opens_before_minutes;
+
+ALTER TABLE classes
+    DROP COLUMN attendance_closes_after_minutes,
+    DROP COLUMN attendance_opens_before_minutes;
+
+COMMIT;
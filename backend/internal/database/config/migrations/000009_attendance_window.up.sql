@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Attendance can be taken from this many minutes before a session starts, until this many minutes after it ends.
+ALTER TABLE classes
+    ADD COLUMN attendance_opens_before_minutes INTEGER NOT NULL DEFAULT 30,
+    ADD COLUMN attendance_closes_after_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT ck_attendance_opens_before_minutes_non_negative
+        CHECK (attendance_opens_before_minutes >= 0),
+    ADD CONSTRAINT ck_attendance_closes_after_minutes_non_negative
+        CHECK (attendance_closes_after_minutes >= 0);
+
+-- Overrides the attendance window of the class for a class group. NULL means the class setting is used.
+ALTER TABLE class_groups
+    ADD COLUMN attendance_opens_before_minutes INTEGER,
+    ADD COLUMN attendance_closes_after_minutes INTEGER,
+    ADD CONSTRAINT ck_attendance_opens_before_minutes_non_negative
+        CHECK (attendance_opens_before_minutes >= 0),
+    ADD CONSTRAINT ck_attendance_closes_after_minutes_non_negative
+        CHECK (attendance_closes_after_minutes >= 0);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE rule_evaluations;
+
+DROP TABLE intervention_runs;
+
+ALTER TABLE class_attendance_rules
+    DROP COLUMN notification_cooldown_days;
+
+COMMIT;
@@ -0,0 +1,43 @@
+BEGIN;
+
+-- Days after a student was last notified of a rule before the student is notified again, while the student keeps
+-- triggering the rule. A student is always notified when the student starts triggering the rule.
+ALTER TABLE class_attendance_rules
+    ADD COLUMN notification_cooldown_days INTEGER NOT NULL DEFAULT 7,
+    ADD CONSTRAINT ck_notification_cooldown_days_non_negative
+        CHECK (notification_cooldown_days >= 0);
+
+CREATE TABLE intervention_runs
+(
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The result of evaluating a rule against a user in an intervention run.
+CREATE TABLE rule_evaluations
+(
+    id         BIGSERIAL PRIMARY KEY,
+    run_id     BIGINT      NOT NULL,
+    rule_id    BIGINT      NOT NULL,
+    user_id    TEXT        NOT NULL,
+    triggered  BOOLEAN     NOT NULL,
+    notified   BOOLEAN     NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ux_run_id_rule_id_user_id
+        UNIQUE (run_id, rule_id, user_id),
+    CONSTRAINT fk_run_id
+        FOREIGN KEY (run_id)
+            REFERENCES intervention_runs (id),
+    CONSTRAINT fk_rule_id
+        FOREIGN KEY (rule_id)
+            REFERENCES class_attendance_rules (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+);
+
+CREATE INDEX ix_rule_id_user_id_created_at
+    ON rule_evaluations (rule_id, user_id, created_at);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE class_attendance_rules
+    DROP COLUMN template_id;
+
+DROP TABLE rule_templates;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+-- A rule that can be instantiated into any class. Templates are published by system admins and course coordinators.
+CREATE TABLE rule_templates
+(
+    id          BIGSERIAL PRIMARY KEY,
+    creator_id  TEXT        NOT NULL,
+    title       TEXT        NOT NULL,
+    description TEXT        NOT NULL,
+    definition  JSONB       NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ux_title
+        UNIQUE (title),
+    CONSTRAINT fk_creator_id
+        FOREIGN KEY (creator_id)
+            REFERENCES users (id)
+);
+
+CREATE TRIGGER update_updated_at
+    BEFORE UPDATE
+    ON rule_templates
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at();
+
+-- The template that a rule was instantiated from, if any.
+ALTER TABLE class_attendance_rules
+    ADD COLUMN template_id BIGINT,
+    ADD CONSTRAINT fk_template_id
+        FOREIGN KEY (template_id)
+            REFERENCES rule_templates (id)
+            ON DELETE SET NULL;
+
+COMMIT;
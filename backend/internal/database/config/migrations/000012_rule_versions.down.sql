@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE rule_evaluations
+    DROP COLUMN rule_version;
+
+DROP TABLE class_attendance_rule_versions;
+
+ALTER TABLE class_attendance_rules
+    DROP COLUMN version;
+
+COMMIT;
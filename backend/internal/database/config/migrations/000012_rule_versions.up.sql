@@ -0,0 +1,45 @@
+BEGIN;
+
+ALTER TABLE class_attendance_rules
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- An immutable snapshot of a rule, created when the rule is created and each time its definition is edited.
+CREATE TABLE class_attendance_rule_versions
+(
+    id          BIGSERIAL PRIMARY KEY,
+    rule_id     BIGINT      NOT NULL,
+    version     INTEGER     NOT NULL,
+    editor_id   TEXT        NOT NULL,
+    title       TEXT        NOT NULL,
+    description TEXT        NOT NULL,
+    rule        TEXT        NOT NULL,
+    environment JSONB       NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ux_rule_id_version
+        UNIQUE (rule_id, version),
+    CONSTRAINT fk_rule_id
+        FOREIGN KEY (rule_id)
+            REFERENCES class_attendance_rules (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_editor_id
+        FOREIGN KEY (editor_id)
+            REFERENCES users (id)
+);
+
+INSERT INTO class_attendance_rule_versions (rule_id, version, editor_id, title, description, rule, environment,
+                                            created_at)
+SELECT id, version, creator_id, title, description, rule, environment, created_at
+FROM class_attendance_rules;
+
+-- The version of the rule that produced each evaluation.
+ALTER TABLE rule_evaluations
+    ADD COLUMN rule_version INTEGER NOT NULL DEFAULT 1,
+    ADD CONSTRAINT fk_rule_id_rule_version
+        FOREIGN KEY (rule_id, rule_version)
+            REFERENCES class_attendance_rule_versions (rule_id, version)
+            ON DELETE CASCADE;
+
+ALTER TABLE rule_evaluations
+    ALTER COLUMN rule_version DROP DEFAULT;
+
+COMMIT;
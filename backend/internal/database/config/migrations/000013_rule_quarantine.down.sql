@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE class_attendance_rules
+    DROP COLUMN quarantine_reason,
+    DROP COLUMN quarantined_at;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- A rule is quarantined when it fails to compile or run during an intervention run. Quarantined rules are skipped in
+-- intervention runs until their definition is changed.
+ALTER TABLE class_attendance_rules
+    ADD COLUMN quarantined_at    TIMESTAMPTZ,
+    ADD COLUMN quarantine_reason TEXT;
+
+COMMIT;
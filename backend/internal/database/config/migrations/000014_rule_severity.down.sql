@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE class_attendance_rules
+    DROP COLUMN escalation_policy,
+    DROP COLUMN severity;
+
+DROP TYPE RULE_SEVERITY;
+
+COMMIT;
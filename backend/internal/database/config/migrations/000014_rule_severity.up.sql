@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TYPE RULE_SEVERITY AS ENUM ('INFO', 'WARNING', 'CRITICAL');
+
+-- The escalation policy decides who else is notified when a student triggers a rule, based on the number of times
+-- the student has been notified of the rule.
+ALTER TABLE class_attendance_rules
+    ADD COLUMN severity          RULE_SEVERITY NOT NULL DEFAULT 'WARNING',
+    ADD COLUMN escalation_policy JSONB         NOT NULL DEFAULT '{"steps": []}';
+
+COMMIT;
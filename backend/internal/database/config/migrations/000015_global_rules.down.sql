@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE global_attendance_rules;
+
+COMMIT;
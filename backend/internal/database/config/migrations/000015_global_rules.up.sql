@@ -0,0 +1,29 @@
+BEGIN;
+
+-- A rule that is evaluated over the facts of a student across all classes, instead of the facts of a single class.
+-- Global rules are managed by system admins, and their results are sent to the student affairs mailbox.
+CREATE TABLE global_attendance_rules
+(
+    id          BIGSERIAL PRIMARY KEY,
+    creator_id  TEXT        NOT NULL,
+    title       TEXT        NOT NULL,
+    description TEXT        NOT NULL,
+    rule        TEXT        NOT NULL,
+    environment JSONB       NOT NULL,
+    active      BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ux_global_rule_title
+        UNIQUE (title),
+    CONSTRAINT fk_creator_id
+        FOREIGN KEY (creator_id)
+            REFERENCES users (id)
+);
+
+CREATE TRIGGER update_updated_at
+    BEFORE UPDATE
+    ON global_attendance_rules
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at();
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- The progress of a job run by the scheduler. The last scheduled time is the latest time in the schedule of the job
+-- that was run, and is used to catch up on runs that were missed while no scheduler was running.
+CREATE TABLE scheduled_jobs
+(
+    name              TEXT PRIMARY KEY,
+    last_scheduled_at TIMESTAMPTZ NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_updated_at
+    BEFORE UPDATE
+    ON scheduled_jobs
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at();
+
+COMMIT;
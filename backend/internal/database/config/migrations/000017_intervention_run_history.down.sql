@@ -0,0 +1,21 @@
+BEGIN;
+
+DROP TABLE intervention_run_mails;
+
+DROP TABLE intervention_run_rule_failures;
+
+ALTER TABLE intervention_runs
+    DROP COLUMN num_failed_mails,
+    DROP COLUMN num_mails,
+    DROP COLUMN num_evaluations,
+    DROP COLUMN num_rules,
+    DROP COLUMN num_facts,
+    DROP COLUMN error,
+    DROP COLUMN ended_at,
+    DROP COLUMN started_at,
+    DROP COLUMN triggered_by,
+    DROP COLUMN scheduled_for;
+
+DROP TYPE MAIL_DELIVERY_STATUS;
+
+COMMIT;
@@ -0,0 +1,74 @@
+BEGIN;
+
+CREATE TYPE MAIL_DELIVERY_STATUS AS ENUM ('SENT', 'FAILED');
+
+-- A run checks the classes that had sessions on the day of its scheduled time, up to that time. Runs triggered
+-- manually record the user who triggered them. A run that has not ended is still in progress, or was interrupted.
+ALTER TABLE intervention_runs
+    ADD COLUMN scheduled_for    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN triggered_by     TEXT,
+    ADD COLUMN started_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN ended_at         TIMESTAMPTZ,
+    ADD COLUMN error            TEXT,
+    ADD COLUMN num_facts        INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN num_rules        INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN num_evaluations  INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN num_mails        INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN num_failed_mails INTEGER     NOT NULL DEFAULT 0,
+    ADD CONSTRAINT fk_triggered_by
+        FOREIGN KEY (triggered_by)
+            REFERENCES users (id);
+
+UPDATE intervention_runs
+SET scheduled_for = created_at,
+    started_at    = created_at,
+    ended_at      = created_at;
+
+-- A rule that failed in an intervention run. Either a class rule or a global rule failed. The title of the rule at the
+-- time of the run is kept, so that the failure is still shown after the rule is deleted.
+CREATE TABLE intervention_run_rule_failures
+(
+    id             BIGSERIAL PRIMARY KEY,
+    run_id         BIGINT      NOT NULL,
+    rule_id        BIGINT,
+    global_rule_id BIGINT,
+    rule_title     TEXT        NOT NULL,
+    reason         TEXT        NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ck_rule_id_or_global_rule_id
+        CHECK (rule_id IS NULL OR global_rule_id IS NULL),
+    CONSTRAINT fk_run_id
+        FOREIGN KEY (run_id)
+            REFERENCES intervention_runs (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_rule_id
+        FOREIGN KEY (rule_id)
+            REFERENCES class_attendance_rules (id)
+            ON DELETE SET NULL,
+    CONSTRAINT fk_global_rule_id
+        FOREIGN KEY (global_rule_id)
+            REFERENCES global_attendance_rules (id)
+            ON DELETE SET NULL
+);
+
+-- A mail sent in an intervention run, and whether it was delivered to the mail transport.
+CREATE TABLE intervention_run_mails
+(
+    id           BIGSERIAL PRIMARY KEY,
+    run_id       BIGINT               NOT NULL,
+    to_addresses TEXT                 NOT NULL,
+    cc_addresses TEXT                 NOT NULL,
+    subject      TEXT                 NOT NULL,
+    status       MAIL_DELIVERY_STATUS NOT NULL,
+    error        TEXT,
+    created_at   TIMESTAMPTZ          NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_run_id
+        FOREIGN KEY (run_id)
+            REFERENCES intervention_runs (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX ix_intervention_run_mails_run_id
+    ON intervention_run_mails (run_id);
+
+COMMIT;
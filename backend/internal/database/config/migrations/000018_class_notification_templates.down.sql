@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE class_notification_templates;
+
+COMMIT;
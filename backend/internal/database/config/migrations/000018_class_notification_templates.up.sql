@@ -0,0 +1,30 @@
+BEGIN;
+
+-- Overrides of the notification templates of a class. A template that is not set falls back to the built-in template.
+-- The user templates override the built-in user templates of every rule severity.
+CREATE TABLE class_notification_templates
+(
+    class_id                   BIGINT PRIMARY KEY,
+    editor_id                  TEXT        NOT NULL,
+    user_text_template         TEXT,
+    user_html_template         TEXT,
+    rule_creator_text_template TEXT,
+    rule_creator_html_template TEXT,
+    created_at                 TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at                 TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_class_id
+        FOREIGN KEY (class_id)
+            REFERENCES classes (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_editor_id
+        FOREIGN KEY (editor_id)
+            REFERENCES users (id)
+);
+
+CREATE TRIGGER update_updated_at
+    BEFORE UPDATE
+    ON class_notification_templates
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at();
+
+COMMIT;
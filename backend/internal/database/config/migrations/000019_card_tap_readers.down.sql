@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE session_enrollments
+    DROP COLUMN reader_id;
+
+COMMIT;
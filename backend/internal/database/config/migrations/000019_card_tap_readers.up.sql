@@ -0,0 +1,8 @@
+BEGIN;
+
+-- The card reader that recorded the attendance of an enrollment. It is cleared when the attendance is recorded by any
+-- other means.
+ALTER TABLE session_enrollments
+    ADD COLUMN reader_id TEXT;
+
+COMMIT;
@@ -2,8 +2,10 @@ package database
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/enum"
 	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
 	"github.com/darylhjd/oams/backend/internal/rules"
@@ -11,12 +13,16 @@ import (
 )
 
 type CoordinatingClass struct {
-	ID        int64  `alias:"class.id" json:"id"`
-	Code      string `alias:"class.code" json:"code"`
-	Year      int32  `alias:"class.year" json:"year"`
-	Semester  string `alias:"class.semester" json:"semester"`
-	Programme string `alias:"class.programme" json:"programme"`
-	Au        int16  `alias:"class.au" json:"au"`
+	ID                           int64      `alias:"class.id" json:"id"`
+	Code                         string     `alias:"class.code" json:"code"`
+	Year                         int32      `alias:"class.year" json:"year"`
+	Semester                     string     `alias:"class.semester" json:"semester"`
+	Programme                    string     `alias:"class.programme" json:"programme"`
+	Au                           int16      `alias:"class.au" json:"au"`
+	LateThresholdMinutes         int32      `alias:"class.late_threshold_minutes" json:"late_threshold_minutes"`
+	AttendanceLockedAfter        *time.Time `alias:"class.attendance_locked_after" json:"attendance_locked_after"`
+	AttendanceOpensBeforeMinutes int32      `alias:"class.attendance_opens_before_minutes" json:"attendance_opens_before_minutes"`
+	AttendanceClosesAfterMinutes int32      `alias:"class.attendance_closes_after_minutes" json:"attendance_closes_after_minutes"`
 }
 
 func (d *DB) GetCoordinatingClasses(ctx context.Context) ([]CoordinatingClass, error) {
@@ -43,6 +49,158 @@ func (d *DB) GetCoordinatingClass(ctx context.Context, id int64) (CoordinatingCl
 	return res, err
 }
 
+// UpdateCoordinatingClassParams contains the settings of a class to update. A nil setting is left unchanged. The
+// attendance lock is left unchanged if AttendanceLockedAfter is not set, and cleared if it is set to a nil time.
+type UpdateCoordinatingClassParams struct {
+	ID                           int64
+	LateThresholdMinutes         *int32
+	AttendanceLockedAfter        Nullable[time.Time]
+	AttendanceOpensBeforeMinutes *int32
+	AttendanceClosesAfterMinutes *int32
+}
+
+func (d *DB) UpdateCoordinatingClass(ctx context.Context, arg UpdateCoordinatingClassParams) (CoordinatingClass, error) {
+	var res CoordinatingClass
+
+	var assignments []any
+
+	if arg.LateThresholdMinutes != nil {
+		assignments = append(assignments, Classes.LateThresholdMinutes.SET(Int32(*arg.LateThresholdMinutes)))
+	}
+
+	if arg.AttendanceLockedAfter.Set {
+		lockedAfter := TimestampzExp(NULL)
+		if arg.AttendanceLockedAfter.Value != nil {
+			lockedAfter = TimestampzT(*arg.AttendanceLockedAfter.Value)
+		}
+
+		assignments = append(assignments, Classes.AttendanceLockedAfter.SET(lockedAfter))
+	}
+
+	if arg.AttendanceOpensBeforeMinutes != nil {
+		assignments = append(assignments,
+			Classes.AttendanceOpensBeforeMinutes.SET(Int32(*arg.AttendanceOpensBeforeMinutes)),
+		)
+	}
+
+	if arg.AttendanceClosesAfterMinutes != nil {
+		assignments = append(assignments,
+			Classes.AttendanceClosesAfterMinutes.SET(Int32(*arg.AttendanceClosesAfterMinutes)),
+		)
+	}
+
+	if len(assignments) == 0 {
+		return res, errors.New("no class settings to update")
+	}
+
+	stmt := Classes.UPDATE().SET(
+		assignments[0], assignments[1:]...,
+	).WHERE(
+		coordinatingClassRLS(ctx).AND(
+			Classes.ID.EQ(Int64(arg.ID)),
+		),
+	).RETURNING(
+		coordinatingClassFields(),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// CoordinatingClassGroup contains the attendance window overrides of a class group. A nil offset means the class
+// setting is used.
+type CoordinatingClassGroup struct {
+	ID                           int64           `alias:"class_group.id" json:"id"`
+	Name                         string          `alias:"class_group.name" json:"name"`
+	ClassType                    model.ClassType `alias:"class_group.class_type" json:"class_type"`
+	AttendanceOpensBeforeMinutes *int32          `alias:"class_group.attendance_opens_before_minutes" json:"attendance_opens_before_minutes"`
+	AttendanceClosesAfterMinutes *int32          `alias:"class_group.attendance_closes_after_minutes" json:"attendance_closes_after_minutes"`
+}
+
+func (d *DB) GetCoordinatingClassGroups(ctx context.Context, id int64) ([]CoordinatingClassGroup, error) {
+	var res []CoordinatingClassGroup
+
+	stmt := SELECT(
+		coordinatingClassGroupFields(),
+	).FROM(
+		ClassGroups.INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassGroups.ClassID),
+		),
+	).WHERE(
+		coordinatingClassRLS(ctx).AND(
+			Classes.ID.EQ(Int64(id)),
+		),
+	).ORDER_BY(
+		ClassGroups.Name,
+		ClassGroups.ClassType,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// UpdateCoordinatingClassGroupParams contains the attendance window overrides of a class group to update. An override
+// is left unchanged if it is not set, and removed if it is set to nil, so that the class setting is used.
+type UpdateCoordinatingClassGroupParams struct {
+	ClassID                      int64
+	GroupID                      int64
+	AttendanceOpensBeforeMinutes Nullable[int32]
+	AttendanceClosesAfterMinutes Nullable[int32]
+}
+
+func (d *DB) UpdateCoordinatingClassGroup(ctx context.Context, arg UpdateCoordinatingClassGroupParams) (CoordinatingClassGroup, error) {
+	var res CoordinatingClassGroup
+
+	override := func(minutes Nullable[int32]) IntegerExpression {
+		if minutes.Value == nil {
+			return IntExp(NULL)
+		}
+
+		return Int32(*minutes.Value)
+	}
+
+	var assignments []any
+
+	if arg.AttendanceOpensBeforeMinutes.Set {
+		assignments = append(assignments,
+			ClassGroups.AttendanceOpensBeforeMinutes.SET(override(arg.AttendanceOpensBeforeMinutes)),
+		)
+	}
+
+	if arg.AttendanceClosesAfterMinutes.Set {
+		assignments = append(assignments,
+			ClassGroups.AttendanceClosesAfterMinutes.SET(override(arg.AttendanceClosesAfterMinutes)),
+		)
+	}
+
+	if len(assignments) == 0 {
+		return res, errors.New("no class group settings to update")
+	}
+
+	stmt := ClassGroups.UPDATE().SET(
+		assignments[0], assignments[1:]...,
+	).WHERE(
+		ClassGroups.ID.EQ(Int64(arg.GroupID)).AND(
+			ClassGroups.ClassID.IN(
+				SELECT(
+					Classes.ID,
+				).FROM(
+					Classes,
+				).WHERE(
+					coordinatingClassRLS(ctx).AND(
+						Classes.ID.EQ(Int64(arg.ClassID)),
+					),
+				),
+			),
+		),
+	).RETURNING(
+		coordinatingClassGroupFields(),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
 func (d *DB) GetCoordinatingClassRules(ctx context.Context, id int64) ([]model.ClassAttendanceRule, error) {
 	var res []model.ClassAttendanceRule
 
@@ -60,13 +218,38 @@ func (d *DB) GetCoordinatingClassRules(ctx context.Context, id int64) ([]model.C
 	return res, err
 }
 
+func (d *DB) GetCoordinatingClassRule(ctx context.Context, classId, ruleId int64) (model.ClassAttendanceRule, error) {
+	var res model.ClassAttendanceRule
+
+	stmt := SELECT(
+		ClassAttendanceRules.AllColumns,
+	).FROM(
+		ClassAttendanceRules.INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassAttendanceRules.ClassID),
+		),
+	).WHERE(
+		coordinatingClassRLS(ctx).AND(
+			Classes.ID.EQ(Int64(classId)),
+		).AND(
+			ClassAttendanceRules.ID.EQ(Int64(ruleId)),
+		),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
 type CreateNewCoordinatingClassRuleParams struct {
-	ClassID     int64
-	CreatorID   string
-	Title       string
-	Description string
-	Rule        string
-	Env         rules.E
+	ClassID                  int64
+	CreatorID                string
+	Title                    string
+	Description              string
+	Rule                     string
+	Env                      rules.E
+	NotificationCooldownDays int32
+	TemplateID               *int64
+	Severity                 model.RuleSeverity
+	EscalationPolicy         rules.EscalationPolicy
 }
 
 func (d *DB) CreateNewCoordinatingClassRule(ctx context.Context, arg CreateNewCoordinatingClassRuleParams) (model.ClassAttendanceRule, error) {
@@ -77,6 +260,16 @@ func (d *DB) CreateNewCoordinatingClassRule(ctx context.Context, arg CreateNewCo
 		return res, err
 	}
 
+	policyString, err := arg.EscalationPolicy.Value()
+	if err != nil {
+		return res, err
+	}
+
+	templateId := CAST(NULL).AS_BIGINT()
+	if arg.TemplateID != nil {
+		templateId = Int64(*arg.TemplateID)
+	}
+
 	stmt := ClassAttendanceRules.INSERT(
 		ClassAttendanceRules.ClassID,
 		ClassAttendanceRules.CreatorID,
@@ -85,6 +278,10 @@ func (d *DB) CreateNewCoordinatingClassRule(ctx context.Context, arg CreateNewCo
 		ClassAttendanceRules.Rule,
 		ClassAttendanceRules.Environment,
 		ClassAttendanceRules.Active,
+		ClassAttendanceRules.NotificationCooldownDays,
+		ClassAttendanceRules.TemplateID,
+		ClassAttendanceRules.Severity,
+		ClassAttendanceRules.EscalationPolicy,
 	).QUERY(
 		SELECT(
 			Int64(arg.ClassID),
@@ -94,6 +291,10 @@ func (d *DB) CreateNewCoordinatingClassRule(ctx context.Context, arg CreateNewCo
 			String(arg.Rule),
 			Json(envString),
 			Bool(true),
+			Int32(arg.NotificationCooldownDays),
+			templateId,
+			ruleSeverity(arg.Severity),
+			Json(policyString),
 		).WHERE(
 			EXISTS(
 				SELECT(
@@ -111,21 +312,85 @@ func (d *DB) CreateNewCoordinatingClassRule(ctx context.Context, arg CreateNewCo
 		ClassAttendanceRules.AllColumns,
 	)
 
-	err = stmt.QueryContext(ctx, d.qe, &res)
-	return res, err
+	if err = stmt.QueryContext(ctx, d.qe, &res); err != nil {
+		return res, err
+	}
+
+	return res, d.createRuleVersions(ctx, arg.CreatorID, res)
+}
+
+type UpdateCoordinatingClassRuleParams struct {
+	EditorID                 string
+	Active                   *bool
+	NotificationCooldownDays *int32
+	Severity                 *model.RuleSeverity
+	EscalationPolicy         *rules.EscalationPolicy
+	Definition               *UpdateCoordinatingClassRuleDefinitionParams
+}
+
+// UpdateCoordinatingClassRuleDefinitionParams contains the verified definition of a rule.
+type UpdateCoordinatingClassRuleDefinitionParams struct {
+	Title       string
+	Description string
+	Rule        string
+	Env         rules.E
 }
 
-func (d *DB) UpdateCoordinatingClassRule(ctx context.Context, classId, ruleId int64, active bool) (bool, error) {
-	var res struct {
-		Active bool `alias:"class_attendance_rule.active"`
+// UpdateCoordinatingClassRule updates a rule of a class. If the definition of the rule is changed, a new version of
+// the rule is created and the rule is unlinked from its template, if any.
+func (d *DB) UpdateCoordinatingClassRule(ctx context.Context, classId, ruleId int64, arg UpdateCoordinatingClassRuleParams) (model.ClassAttendanceRule, error) {
+	var res model.ClassAttendanceRule
+
+	var assignments []any
+
+	if arg.Active != nil {
+		assignments = append(assignments, ClassAttendanceRules.Active.SET(Bool(*arg.Active)))
 	}
 
-	stmt := ClassAttendanceRules.UPDATE(
-		ClassAttendanceRules.Active,
-	).MODEL(
-		model.ClassAttendanceRule{
-			Active: active,
-		},
+	if arg.NotificationCooldownDays != nil {
+		assignments = append(assignments,
+			ClassAttendanceRules.NotificationCooldownDays.SET(Int32(*arg.NotificationCooldownDays)),
+		)
+	}
+
+	if arg.Severity != nil {
+		assignments = append(assignments, ClassAttendanceRules.Severity.SET(ruleSeverity(*arg.Severity)))
+	}
+
+	if arg.EscalationPolicy != nil {
+		policyString, err := arg.EscalationPolicy.Value()
+		if err != nil {
+			return res, err
+		}
+
+		assignments = append(assignments, ClassAttendanceRules.EscalationPolicy.SET(Json(policyString)))
+	}
+
+	if def := arg.Definition; def != nil {
+		envString, err := (&rules.Environment{Env: def.Env}).Value()
+		if err != nil {
+			return res, err
+		}
+
+		assignments = append(assignments,
+			ClassAttendanceRules.Title.SET(String(def.Title)),
+			ClassAttendanceRules.Description.SET(String(def.Description)),
+			ClassAttendanceRules.Rule.SET(String(def.Rule)),
+			ClassAttendanceRules.Environment.SET(Json(envString)),
+			ClassAttendanceRules.Version.SET(ClassAttendanceRules.Version.ADD(Int32(1))),
+			ClassAttendanceRules.TemplateID.SET(IntExp(NULL)),
+			ClassAttendanceRules.QuarantinedAt.SET(TimestampzExp(NULL)),
+			ClassAttendanceRules.QuarantineReason.SET(StringExp(NULL)),
+		)
+	}
+
+	if len(assignments) == 0 {
+		// Nothing to update, so the rule is returned as it is.
+		assignments = append(assignments, ClassAttendanceRules.Active.SET(ClassAttendanceRules.Active))
+	}
+
+	stmt := ClassAttendanceRules.UPDATE().SET(
+		assignments[0], assignments[1:]...,
 	).WHERE(
 		EXISTS(
 			SELECT(
@@ -137,6 +402,8 @@ func (d *DB) UpdateCoordinatingClassRule(ctx context.Context, classId, ruleId in
 					Classes.ID.EQ(Int64(classId)),
 				),
 			),
+		).AND(
+			ClassAttendanceRules.ClassID.EQ(Int64(classId)),
 		).AND(
 			ClassAttendanceRules.ID.EQ(Int64(ruleId)),
 		),
@@ -144,8 +411,15 @@ func (d *DB) UpdateCoordinatingClassRule(ctx context.Context, classId, ruleId in
 		ClassAttendanceRules.AllColumns,
 	)
 
-	err := stmt.QueryContext(ctx, d.qe, &res)
-	return res.Active, err
+	if err := stmt.QueryContext(ctx, d.qe, &res); err != nil {
+		return res, err
+	}
+
+	if arg.Definition == nil {
+		return res, nil
+	}
+
+	return res, d.createRuleVersions(ctx, arg.EditorID, res)
 }
 
 func (d *DB) DeleteCoordinatingClassRule(ctx context.Context, classId, ruleId int64) error {
@@ -287,6 +561,8 @@ type AttendanceCountData struct {
 	ClassGroupName string `alias:"class_group.name" json:"class_group_name"`
 	Attended       int    `alias:".attended" json:"attended"`
 	NotAttended    int    `alias:".not_attended" json:"not_attended"`
+	Late           int    `alias:".late" json:"late"`
+	Excused        int    `alias:".excused" json:"excused"`
 }
 
 func (d *DB) GetDashboardData(ctx context.Context, id int64) ([]AttendanceCountData, error) {
@@ -294,8 +570,10 @@ func (d *DB) GetDashboardData(ctx context.Context, id int64) ([]AttendanceCountD
 
 	stmt := SELECT(
 		ClassGroups.Name,
-		SUM(CASE().WHEN(SessionEnrollments.Attended.IS_TRUE()).THEN(Int64(1)).ELSE(Int64(0))).AS("attended"),
-		SUM(CASE().WHEN(SessionEnrollments.Attended.IS_FALSE()).THEN(Int64(1)).ELSE(Int64(0))).AS("not_attended"),
+		SUM(CASE().WHEN(isAttended()).THEN(Int64(1)).ELSE(Int64(0))).AS("attended"),
+		SUM(CASE().WHEN(SessionEnrollments.Status.EQ(AttendanceStatus.Absent)).THEN(Int64(1)).ELSE(Int64(0))).AS("not_attended"),
+		SUM(CASE().WHEN(SessionEnrollments.Status.EQ(AttendanceStatus.Late)).THEN(Int64(1)).ELSE(Int64(0))).AS("late"),
+		SUM(CASE().WHEN(SessionEnrollments.Status.EQ(AttendanceStatus.Excused)).THEN(Int64(1)).ELSE(Int64(0))).AS("excused"),
 	).FROM(
 		Classes.INNER_JOIN(
 			ClassGroups, ClassGroups.ClassID.EQ(Classes.ID),
@@ -394,7 +672,11 @@ func (d *DB) GetCoordinatingClassScheduleAttendance(ctx context.Context, classId
 		SessionEnrollments.SessionID,
 		SessionEnrollments.UserID,
 		Users.Name,
-		SessionEnrollments.Attended,
+		SessionEnrollments.Status,
+		SessionEnrollments.MarkedBy,
+		SessionEnrollments.CheckedInAt,
+		SessionEnrollments.Source,
+		SessionEnrollments.ReaderID,
 	).FROM(
 		SessionEnrollments.INNER_JOIN(
 			ClassGroupSessions, ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID),
@@ -465,12 +747,7 @@ func (d *DB) UpdateCoordinatingClassSchedule(ctx context.Context, arg UpdateCoor
 
 func selectCoordinatingClassFields() SelectStatement {
 	return SELECT(
-		Classes.ID,
-		Classes.Code,
-		Classes.Year,
-		Classes.Semester,
-		Classes.Programme,
-		Classes.Au,
+		coordinatingClassFields(),
 	).FROM(
 		Classes,
 	).ORDER_BY(
@@ -478,3 +755,32 @@ func selectCoordinatingClassFields() SelectStatement {
 		Classes.Semester.DESC(),
 	)
 }
+
+func coordinatingClassFields() ColumnList {
+	return ColumnList{
+		Classes.ID,
+		Classes.Code,
+		Classes.Year,
+		Classes.Semester,
+		Classes.Programme,
+		Classes.Au,
+		Classes.LateThresholdMinutes,
+		Classes.AttendanceLockedAfter,
+		Classes.AttendanceOpensBeforeMinutes,
+		Classes.AttendanceClosesAfterMinutes,
+	}
+}
+
+func coordinatingClassGroupFields() ColumnList {
+	return ColumnList{
+		ClassGroups.ID,
+		ClassGroups.Name,
+		ClassGroups.ClassType,
+		ClassGroups.AttendanceOpensBeforeMinutes,
+		ClassGroups.AttendanceClosesAfterMinutes,
+	}
+}
+
+func ruleSeverity(severity model.RuleSeverity) StringExpression {
+	return StringExp(CAST(String(string(severity))).AS("rule_severity"))
+}
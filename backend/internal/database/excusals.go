@@ -0,0 +1,348 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/enum"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// Excusal contains information on an excusal and the sessions it covers. The supporting document is not included.
+type Excusal struct {
+	ID            int64               `alias:"excusal.id" sql:"primary_key" json:"id"`
+	ClassID       int64               `alias:"excusal.class_id" json:"class_id"`
+	ClassCode     string              `alias:"class.code" json:"class_code"`
+	ClassYear     int32               `alias:"class.year" json:"class_year"`
+	ClassSemester string              `alias:"class.semester" json:"class_semester"`
+	UserID        string              `alias:"excusal.user_id" json:"user_id"`
+	UserName      string              `alias:"user.name" json:"user_name"`
+	Reason        string              `alias:"excusal.reason" json:"reason"`
+	DocumentName  string              `alias:"excusal.document_name" json:"document_name"`
+	DocumentType  string              `alias:"excusal.document_type" json:"document_type"`
+	Status        model.ExcusalStatus `alias:"excusal.status" json:"status"`
+	ReviewerID    *string             `alias:"excusal.reviewer_id" json:"reviewer_id"`
+	ReviewComment string              `alias:"excusal.review_comment" json:"review_comment"`
+	ReviewedAt    *time.Time          `alias:"excusal.reviewed_at" json:"reviewed_at"`
+	CreatedAt     time.Time           `alias:"excusal.created_at" json:"created_at"`
+	Sessions      []ExcusalSession    `json:"sessions"`
+}
+
+// ExcusalSession contains information on a session that is covered by an excusal.
+type ExcusalSession struct {
+	SessionEnrollmentID int64                  `alias:"session_enrollment.id" sql:"primary_key" json:"session_enrollment_id"`
+	SessionID           int64                  `alias:"session_enrollment.session_id" json:"session_id"`
+	ClassGroupName      string                 `alias:"class_group.name" json:"class_group_name"`
+	ClassType           model.ClassType        `alias:"class_group.class_type" json:"class_type"`
+	StartTime           time.Time              `alias:"class_group_session.start_time" json:"start_time"`
+	EndTime             time.Time              `alias:"class_group_session.end_time" json:"end_time"`
+	Venue               string                 `alias:"class_group_session.venue" json:"venue"`
+	Status              model.AttendanceStatus `alias:"session_enrollment.status" json:"status"`
+}
+
+func (d *DB) GetExcusals(ctx context.Context) ([]Excusal, error) {
+	var res []Excusal
+
+	stmt := selectExcusalFields().WHERE(
+		excusalRLS(ctx),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func (d *DB) GetExcusal(ctx context.Context, id int64) (Excusal, error) {
+	var res Excusal
+
+	stmt := selectExcusalFields().WHERE(
+		excusalRLS(ctx).AND(
+			Excusals.ID.EQ(Int64(id)),
+		),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func (d *DB) GetExcusalDocument(ctx context.Context, id int64) (model.Excusal, error) {
+	var res model.Excusal
+
+	stmt := SELECT(
+		Excusals.ID,
+		Excusals.DocumentName,
+		Excusals.DocumentType,
+		Excusals.Document,
+	).FROM(
+		Excusals,
+	).WHERE(
+		excusalRLS(ctx).AND(
+			Excusals.ID.EQ(Int64(id)),
+		),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type CreateExcusalParams struct {
+	Reason               string
+	DocumentName         string
+	DocumentType         string
+	Document             []byte
+	SessionEnrollmentIDs []int64
+}
+
+// CreateExcusal creates an excusal for the current user. All session enrollments must belong to the current user and
+// must be for sessions of the same class.
+func (d *DB) CreateExcusal(ctx context.Context, arg CreateExcusalParams) (Excusal, error) {
+	var res Excusal
+
+	enrollmentIds := make([]int64, 0, len(arg.SessionEnrollmentIDs))
+	enrollmentIdExps := make([]Expression, 0, len(arg.SessionEnrollmentIDs))
+	{
+		dupFinder := map[int64]struct{}{}
+		for _, id := range arg.SessionEnrollmentIDs {
+			if _, ok := dupFinder[id]; !ok {
+				dupFinder[id] = struct{}{}
+				enrollmentIds = append(enrollmentIds, id)
+				enrollmentIdExps = append(enrollmentIdExps, Int64(id))
+			}
+		}
+	}
+
+	if len(enrollmentIds) == 0 {
+		return res, qrm.ErrNoRows
+	}
+
+	userId := oauth2.GetAuthContext(ctx).User.ID
+
+	var class struct {
+		ID int64 `alias:"class_group.class_id"`
+	}
+
+	classStmt := SELECT(
+		MAX(ClassGroups.ClassID).AS("class_group.class_id"),
+	).FROM(
+		SessionEnrollments.INNER_JOIN(
+			ClassGroupSessions, ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID),
+		).INNER_JOIN(
+			ClassGroups, ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
+		),
+	).WHERE(
+		SessionEnrollments.UserID.EQ(String(userId)).AND(
+			SessionEnrollments.ID.IN(enrollmentIdExps...),
+		),
+	).HAVING(
+		COUNT(STAR).EQ(Int64(int64(len(enrollmentIds)))).AND(
+			COUNT(DISTINCT(ClassGroups.ClassID)).EQ(Int64(1)),
+		),
+	)
+
+	if err := classStmt.QueryContext(ctx, d.qe, &class); err != nil {
+		return res, err
+	}
+
+	var excusal model.Excusal
+
+	stmt := Excusals.INSERT(
+		Excusals.ClassID,
+		Excusals.UserID,
+		Excusals.Reason,
+		Excusals.DocumentName,
+		Excusals.DocumentType,
+		Excusals.Document,
+	).MODEL(
+		model.Excusal{
+			ClassID:      class.ID,
+			UserID:       userId,
+			Reason:       arg.Reason,
+			DocumentName: arg.DocumentName,
+			DocumentType: arg.DocumentType,
+			Document:     arg.Document,
+		},
+	).RETURNING(
+		Excusals.ID,
+	)
+
+	if err := stmt.QueryContext(ctx, d.qe, &excusal); err != nil {
+		return res, err
+	}
+
+	sessions := make([]model.ExcusalSessionEnrollment, 0, len(enrollmentIds))
+	for _, id := range enrollmentIds {
+		sessions = append(sessions, model.ExcusalSessionEnrollment{
+			ExcusalID:           excusal.ID,
+			SessionEnrollmentID: id,
+		})
+	}
+
+	sessionsStmt := ExcusalSessionEnrollments.INSERT(
+		ExcusalSessionEnrollments.ExcusalID,
+		ExcusalSessionEnrollments.SessionEnrollmentID,
+	).MODELS(
+		sessions,
+	)
+
+	if _, err := sessionsStmt.ExecContext(ctx, d.qe); err != nil {
+		return res, err
+	}
+
+	return d.GetExcusal(ctx, excusal.ID)
+}
+
+func (d *DB) GetCoordinatingClassExcusals(ctx context.Context, classId int64) ([]Excusal, error) {
+	var res []Excusal
+
+	stmt := selectExcusalFields().WHERE(
+		coordinatingClassRLS(ctx).AND(
+			Excusals.ClassID.EQ(Int64(classId)),
+		),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type ReviewCoordinatingClassExcusalParams struct {
+	ClassID       int64
+	ExcusalID     int64
+	Status        model.ExcusalStatus
+	ReviewComment string
+}
+
+// ReviewCoordinatingClassExcusal approves or rejects a pending excusal. If the excusal is approved, the session
+// enrollments covered by the excusal are marked as excused.
+func (d *DB) ReviewCoordinatingClassExcusal(ctx context.Context, arg ReviewCoordinatingClassExcusalParams) (Excusal, error) {
+	var res Excusal
+
+	reviewerId := oauth2.GetAuthContext(ctx).User.ID
+
+	var excusal model.Excusal
+
+	stmt := Excusals.UPDATE(
+		Excusals.Status,
+		Excusals.ReviewerID,
+		Excusals.ReviewComment,
+		Excusals.ReviewedAt,
+	).MODEL(
+		model.Excusal{
+			Status:        arg.Status,
+			ReviewerID:    &reviewerId,
+			ReviewComment: arg.ReviewComment,
+			ReviewedAt:    to.Ptr(time.Now()),
+		},
+	).WHERE(
+		EXISTS(
+			SELECT(
+				Classes.AllColumns,
+			).FROM(
+				Classes,
+			).WHERE(
+				coordinatingClassRLS(ctx).AND(
+					Classes.ID.EQ(Int64(arg.ClassID)),
+				),
+			),
+		).AND(
+			Excusals.ClassID.EQ(Int64(arg.ClassID)),
+		).AND(
+			Excusals.ID.EQ(Int64(arg.ExcusalID)),
+		).AND(
+			Excusals.Status.EQ(ExcusalStatus.Pending),
+		),
+	).RETURNING(
+		Excusals.ID,
+		Excusals.Status,
+	)
+
+	if err := stmt.QueryContext(ctx, d.qe, &excusal); err != nil {
+		return res, err
+	}
+
+	if excusal.Status == model.ExcusalStatus_Approved {
+		status := attendanceStatus(model.AttendanceStatus_Excused)
+		enrollmentsStmt := SessionEnrollments.UPDATE(
+			SessionEnrollments.Status,
+			SessionEnrollments.MarkedBy,
+			SessionEnrollments.CheckedInAt,
+			SessionEnrollments.Source,
+			SessionEnrollments.ReaderID,
+		).SET(
+			status,
+			String(reviewerId),
+			checkedInAt(status, TimestampzT(time.Now())),
+			AttendanceSource.Manual,
+			StringExp(NULL),
+		).WHERE(
+			SessionEnrollments.ID.IN(
+				SELECT(
+					ExcusalSessionEnrollments.SessionEnrollmentID,
+				).FROM(
+					ExcusalSessionEnrollments,
+				).WHERE(
+					ExcusalSessionEnrollments.ExcusalID.EQ(Int64(excusal.ID)),
+				),
+			),
+		)
+
+		if _, err := enrollmentsStmt.ExecContext(ctx, d.qe); err != nil {
+			return res, err
+		}
+	}
+
+	selectStmt := selectExcusalFields().WHERE(
+		Excusals.ID.EQ(Int64(excusal.ID)),
+	)
+
+	err := selectStmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func selectExcusalFields() SelectStatement {
+	return SELECT(
+		Excusals.ID,
+		Excusals.ClassID,
+		Classes.Code,
+		Classes.Year,
+		Classes.Semester,
+		Excusals.UserID,
+		Users.Name,
+		Excusals.Reason,
+		Excusals.DocumentName,
+		Excusals.DocumentType,
+		Excusals.Status,
+		Excusals.ReviewerID,
+		Excusals.ReviewComment,
+		Excusals.ReviewedAt,
+		Excusals.CreatedAt,
+		SessionEnrollments.ID,
+		SessionEnrollments.SessionID,
+		ClassGroups.Name,
+		ClassGroups.ClassType,
+		ClassGroupSessions.StartTime,
+		ClassGroupSessions.EndTime,
+		ClassGroupSessions.Venue,
+		SessionEnrollments.Status,
+	).FROM(
+		Excusals.INNER_JOIN(
+			Classes, Classes.ID.EQ(Excusals.ClassID),
+		).INNER_JOIN(
+			Users, Users.ID.EQ(Excusals.UserID),
+		).INNER_JOIN(
+			ExcusalSessionEnrollments, ExcusalSessionEnrollments.ExcusalID.EQ(Excusals.ID),
+		).INNER_JOIN(
+			SessionEnrollments, SessionEnrollments.ID.EQ(ExcusalSessionEnrollments.SessionEnrollmentID),
+		).INNER_JOIN(
+			ClassGroupSessions, ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID),
+		).INNER_JOIN(
+			ClassGroups, ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
+		),
+	).ORDER_BY(
+		Excusals.CreatedAt.DESC(),
+		ClassGroupSessions.StartTime,
+	)
+}
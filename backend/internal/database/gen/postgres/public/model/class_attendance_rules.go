@@ -13,14 +13,21 @@ import (
 )
 
 type ClassAttendanceRule struct {
-	ID          int64             `sql:"primary_key" json:"id"`
-	ClassID     int64             `json:"class_id"`
-	CreatorID   string            `json:"creator_id"`
-	Title       string            `json:"title"`
-	Description string            `json:"description"`
-	Rule        string            `json:"rule"`
-	Environment rules.Environment `json:"environment"`
-	Active      bool              `json:"active"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ID                       int64                  `sql:"primary_key" json:"id"`
+	ClassID                  int64                  `json:"class_id"`
+	CreatorID                string                 `json:"creator_id"`
+	Title                    string                 `json:"title"`
+	Description              string                 `json:"description"`
+	Rule                     string                 `json:"rule"`
+	Environment              rules.Environment      `json:"environment"`
+	Active                   bool                   `json:"active"`
+	CreatedAt                time.Time              `json:"created_at"`
+	UpdatedAt                time.Time              `json:"updated_at"`
+	NotificationCooldownDays int32                  `json:"notification_cooldown_days"`
+	TemplateID               *int64                 `json:"template_id"`
+	Version                  int32                  `json:"version"`
+	QuarantinedAt            *time.Time             `json:"quarantined_at"`
+	QuarantineReason         *string                `json:"quarantine_reason"`
+	Severity                 RuleSeverity           `json:"severity"`
+	EscalationPolicy         rules.EscalationPolicy `json:"escalation_policy"`
 }
@@ -12,10 +12,12 @@ import (
 )
 
 type ClassGroup struct {
-	ID        int64     `sql:"primary_key" json:"id"`
-	ClassID   int64     `json:"class_id"`
-	Name      string    `json:"name"`
-	ClassType ClassType `json:"class_type"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                           int64     `sql:"primary_key" json:"id"`
+	ClassID                      int64     `json:"class_id"`
+	Name                         string    `json:"name"`
+	ClassType                    ClassType `json:"class_type"`
+	CreatedAt                    time.Time `json:"created_at"`
+	UpdatedAt                    time.Time `json:"updated_at"`
+	AttendanceOpensBeforeMinutes *int32    `json:"attendance_opens_before_minutes"`
+	AttendanceClosesAfterMinutes *int32    `json:"attendance_closes_after_minutes"`
 }
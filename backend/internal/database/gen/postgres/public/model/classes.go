@@ -12,12 +12,16 @@ import (
 )
 
 type Class struct {
-	ID        int64     `sql:"primary_key" json:"id"`
-	Code      string    `json:"code"`
-	Year      int32     `json:"year"`
-	Semester  string    `json:"semester"`
-	Programme string    `json:"programme"`
-	Au        int16     `json:"au"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                           int64      `sql:"primary_key" json:"id"`
+	Code                         string     `json:"code"`
+	Year                         int32      `json:"year"`
+	Semester                     string     `json:"semester"`
+	Programme                    string     `json:"programme"`
+	Au                           int16      `json:"au"`
+	CreatedAt                    time.Time  `json:"created_at"`
+	UpdatedAt                    time.Time  `json:"updated_at"`
+	LateThresholdMinutes         int32      `json:"late_threshold_minutes"`
+	AttendanceLockedAfter        *time.Time `json:"attendance_locked_after"`
+	AttendanceOpensBeforeMinutes int32      `json:"attendance_opens_before_minutes"`
+	AttendanceClosesAfterMinutes int32      `json:"attendance_closes_after_minutes"`
 }
@@ -12,10 +12,15 @@ import (
 )
 
 type SessionEnrollment struct {
-	ID        int64     `sql:"primary_key" json:"id"`
-	SessionID int64     `json:"session_id"`
-	UserID    string    `json:"user_id"`
-	Attended  bool      `json:"attended"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                      int64            `sql:"primary_key" json:"id"`
+	SessionID               int64            `json:"session_id"`
+	UserID                  string           `json:"user_id"`
+	CreatedAt               time.Time        `json:"created_at"`
+	UpdatedAt               time.Time        `json:"updated_at"`
+	Status                  AttendanceStatus `json:"status"`
+	MarkedBy                *string          `json:"marked_by"`
+	CheckedInAt             *time.Time       `json:"checked_in_at"`
+	Source                  AttendanceSource `json:"source"`
+	SignatureFailedAttempts int32            `json:"signature_failed_attempts"`
+	ReaderID                *string          `json:"reader_id"`
 }
@@ -12,7 +12,10 @@ import (
 )
 
 type UserSignature struct {
-	UserID    string    `sql:"primary_key" json:"user_id"`
-	Signature string    `json:"signature"`
-	UpdatedAt time.Time `json:"updated_at"`
+	UserID         string     `sql:"primary_key" json:"user_id"`
+	Signature      string     `json:"signature"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	FailedAttempts int32      `json:"failed_attempts"`
+	Lockouts       int32      `json:"lockouts"`
+	LockedUntil    *time.Time `json:"locked_until"`
 }
@@ -17,16 +17,23 @@ type classAttendanceRulesTable struct {
 	postgres.Table
 
 	// Columns
-	ID          postgres.ColumnInteger
-	ClassID     postgres.ColumnInteger
-	CreatorID   postgres.ColumnString
-	Title       postgres.ColumnString
-	Description postgres.ColumnString
-	Rule        postgres.ColumnString
-	Environment postgres.ColumnString
-	Active      postgres.ColumnBool
-	CreatedAt   postgres.ColumnTimestampz
-	UpdatedAt   postgres.ColumnTimestampz
+	ID                       postgres.ColumnInteger
+	ClassID                  postgres.ColumnInteger
+	CreatorID                postgres.ColumnString
+	Title                    postgres.ColumnString
+	Description              postgres.ColumnString
+	Rule                     postgres.ColumnString
+	Environment              postgres.ColumnString
+	Active                   postgres.ColumnBool
+	CreatedAt                postgres.ColumnTimestampz
+	UpdatedAt                postgres.ColumnTimestampz
+	NotificationCooldownDays postgres.ColumnInteger
+	TemplateID               postgres.ColumnInteger
+	Version                  postgres.ColumnInteger
+	QuarantinedAt            postgres.ColumnTimestampz
+	QuarantineReason         postgres.ColumnString
+	Severity                 postgres.ColumnString
+	EscalationPolicy         postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -67,34 +74,48 @@ func newClassAttendanceRulesTable(schemaName, tableName, alias string) *ClassAtt
 
 func newClassAttendanceRulesTableImpl(schemaName, tableName, alias string) classAttendanceRulesTable {
 	var (
-		IDColumn          = postgres.IntegerColumn("id")
-		ClassIDColumn     = postgres.IntegerColumn("class_id")
-		CreatorIDColumn   = postgres.StringColumn("creator_id")
-		TitleColumn       = postgres.StringColumn("title")
-		DescriptionColumn = postgres.StringColumn("description")
-		RuleColumn        = postgres.StringColumn("rule")
-		EnvironmentColumn = postgres.StringColumn("environment")
-		ActiveColumn      = postgres.BoolColumn("active")
-		CreatedAtColumn   = postgres.TimestampzColumn("created_at")
-		UpdatedAtColumn   = postgres.TimestampzColumn("updated_at")
-		allColumns        = postgres.ColumnList{IDColumn, ClassIDColumn, CreatorIDColumn, TitleColumn, DescriptionColumn, RuleColumn, EnvironmentColumn, ActiveColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns    = postgres.ColumnList{ClassIDColumn, CreatorIDColumn, TitleColumn, DescriptionColumn, RuleColumn, EnvironmentColumn, ActiveColumn, CreatedAtColumn, UpdatedAtColumn}
+		IDColumn                       = postgres.IntegerColumn("id")
+		ClassIDColumn                  = postgres.IntegerColumn("class_id")
+		CreatorIDColumn                = postgres.StringColumn("creator_id")
+		TitleColumn                    = postgres.StringColumn("title")
+		DescriptionColumn              = postgres.StringColumn("description")
+		RuleColumn                     = postgres.StringColumn("rule")
+		EnvironmentColumn              = postgres.StringColumn("environment")
+		ActiveColumn                   = postgres.BoolColumn("active")
+		CreatedAtColumn                = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn                = postgres.TimestampzColumn("updated_at")
+		NotificationCooldownDaysColumn = postgres.IntegerColumn("notification_cooldown_days")
+		TemplateIDColumn               = postgres.IntegerColumn("template_id")
+		VersionColumn                  = postgres.IntegerColumn("version")
+		QuarantinedAtColumn            = postgres.TimestampzColumn("quarantined_at")
+		QuarantineReasonColumn         = postgres.StringColumn("quarantine_reason")
+		SeverityColumn                 = postgres.StringColumn("severity")
+		EscalationPolicyColumn         = postgres.StringColumn("escalation_policy")
+		allColumns                     = postgres.ColumnList{IDColumn, ClassIDColumn, CreatorIDColumn, TitleColumn, DescriptionColumn, RuleColumn, EnvironmentColumn, ActiveColumn, CreatedAtColumn, UpdatedAtColumn, NotificationCooldownDaysColumn, TemplateIDColumn, VersionColumn, QuarantinedAtColumn, QuarantineReasonColumn, SeverityColumn, EscalationPolicyColumn}
+		mutableColumns                 = postgres.ColumnList{ClassIDColumn, CreatorIDColumn, TitleColumn, DescriptionColumn, RuleColumn, EnvironmentColumn, ActiveColumn, CreatedAtColumn, UpdatedAtColumn, NotificationCooldownDaysColumn, TemplateIDColumn, VersionColumn, QuarantinedAtColumn, QuarantineReasonColumn, SeverityColumn, EscalationPolicyColumn}
 	)
 
 	return classAttendanceRulesTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:          IDColumn,
-		ClassID:     ClassIDColumn,
-		CreatorID:   CreatorIDColumn,
-		Title:       TitleColumn,
-		Description: DescriptionColumn,
-		Rule:        RuleColumn,
-		Environment: EnvironmentColumn,
-		Active:      ActiveColumn,
-		CreatedAt:   CreatedAtColumn,
-		UpdatedAt:   UpdatedAtColumn,
+		ID:                       IDColumn,
+		ClassID:                  ClassIDColumn,
+		CreatorID:                CreatorIDColumn,
+		Title:                    TitleColumn,
+		Description:              DescriptionColumn,
+		Rule:                     RuleColumn,
+		Environment:              EnvironmentColumn,
+		Active:                   ActiveColumn,
+		CreatedAt:                CreatedAtColumn,
+		UpdatedAt:                UpdatedAtColumn,
+		NotificationCooldownDays: NotificationCooldownDaysColumn,
+		TemplateID:               TemplateIDColumn,
+		Version:                  VersionColumn,
+		QuarantinedAt:            QuarantinedAtColumn,
+		QuarantineReason:         QuarantineReasonColumn,
+		Severity:                 SeverityColumn,
+		EscalationPolicy:         EscalationPolicyColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -17,12 +17,14 @@ type classGroupsTable struct {
 	postgres.Table
 
 	// Columns
-	ID        postgres.ColumnInteger
-	ClassID   postgres.ColumnInteger
-	Name      postgres.ColumnString
-	ClassType postgres.ColumnString
-	CreatedAt postgres.ColumnTimestampz
-	UpdatedAt postgres.ColumnTimestampz
+	ID                           postgres.ColumnInteger
+	ClassID                      postgres.ColumnInteger
+	Name                         postgres.ColumnString
+	ClassType                    postgres.ColumnString
+	CreatedAt                    postgres.ColumnTimestampz
+	UpdatedAt                    postgres.ColumnTimestampz
+	AttendanceOpensBeforeMinutes postgres.ColumnInteger
+	AttendanceClosesAfterMinutes postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -63,26 +65,30 @@ func newClassGroupsTable(schemaName, tableName, alias string) *ClassGroupsTable
 
 func newClassGroupsTableImpl(schemaName, tableName, alias string) classGroupsTable {
 	var (
-		IDColumn        = postgres.IntegerColumn("id")
-		ClassIDColumn   = postgres.IntegerColumn("class_id")
-		NameColumn      = postgres.StringColumn("name")
-		ClassTypeColumn = postgres.StringColumn("class_type")
-		CreatedAtColumn = postgres.TimestampzColumn("created_at")
-		UpdatedAtColumn = postgres.TimestampzColumn("updated_at")
-		allColumns      = postgres.ColumnList{IDColumn, ClassIDColumn, NameColumn, ClassTypeColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns  = postgres.ColumnList{ClassIDColumn, NameColumn, ClassTypeColumn, CreatedAtColumn, UpdatedAtColumn}
+		IDColumn                           = postgres.IntegerColumn("id")
+		ClassIDColumn                      = postgres.IntegerColumn("class_id")
+		NameColumn                         = postgres.StringColumn("name")
+		ClassTypeColumn                    = postgres.StringColumn("class_type")
+		CreatedAtColumn                    = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn                    = postgres.TimestampzColumn("updated_at")
+		AttendanceOpensBeforeMinutesColumn = postgres.IntegerColumn("attendance_opens_before_minutes")
+		AttendanceClosesAfterMinutesColumn = postgres.IntegerColumn("attendance_closes_after_minutes")
+		allColumns                         = postgres.ColumnList{IDColumn, ClassIDColumn, NameColumn, ClassTypeColumn, CreatedAtColumn, UpdatedAtColumn, AttendanceOpensBeforeMinutesColumn, AttendanceClosesAfterMinutesColumn}
+		mutableColumns                     = postgres.ColumnList{ClassIDColumn, NameColumn, ClassTypeColumn, CreatedAtColumn, UpdatedAtColumn, AttendanceOpensBeforeMinutesColumn, AttendanceClosesAfterMinutesColumn}
 	)
 
 	return classGroupsTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:        IDColumn,
-		ClassID:   ClassIDColumn,
-		Name:      NameColumn,
-		ClassType: ClassTypeColumn,
-		CreatedAt: CreatedAtColumn,
-		UpdatedAt: UpdatedAtColumn,
+		ID:                           IDColumn,
+		ClassID:                      ClassIDColumn,
+		Name:                         NameColumn,
+		ClassType:                    ClassTypeColumn,
+		CreatedAt:                    CreatedAtColumn,
+		UpdatedAt:                    UpdatedAtColumn,
+		AttendanceOpensBeforeMinutes: AttendanceOpensBeforeMinutesColumn,
+		AttendanceClosesAfterMinutes: AttendanceClosesAfterMinutesColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
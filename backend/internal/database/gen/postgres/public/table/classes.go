@@ -17,14 +17,18 @@ type classesTable struct {
 	postgres.Table
 
 	// Columns
-	ID        postgres.ColumnInteger
-	Code      postgres.ColumnString
-	Year      postgres.ColumnInteger
-	Semester  postgres.ColumnString
-	Programme postgres.ColumnString
-	Au        postgres.ColumnInteger
-	CreatedAt postgres.ColumnTimestampz
-	UpdatedAt postgres.ColumnTimestampz
+	ID                           postgres.ColumnInteger
+	Code                         postgres.ColumnString
+	Year                         postgres.ColumnInteger
+	Semester                     postgres.ColumnString
+	Programme                    postgres.ColumnString
+	Au                           postgres.ColumnInteger
+	CreatedAt                    postgres.ColumnTimestampz
+	UpdatedAt                    postgres.ColumnTimestampz
+	LateThresholdMinutes         postgres.ColumnInteger
+	AttendanceLockedAfter        postgres.ColumnTimestampz
+	AttendanceOpensBeforeMinutes postgres.ColumnInteger
+	AttendanceClosesAfterMinutes postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -65,30 +69,38 @@ func newClassesTable(schemaName, tableName, alias string) *ClassesTable {
 
 func newClassesTableImpl(schemaName, tableName, alias string) classesTable {
 	var (
-		IDColumn        = postgres.IntegerColumn("id")
-		CodeColumn      = postgres.StringColumn("code")
-		YearColumn      = postgres.IntegerColumn("year")
-		SemesterColumn  = postgres.StringColumn("semester")
-		ProgrammeColumn = postgres.StringColumn("programme")
-		AuColumn        = postgres.IntegerColumn("au")
-		CreatedAtColumn = postgres.TimestampzColumn("created_at")
-		UpdatedAtColumn = postgres.TimestampzColumn("updated_at")
-		allColumns      = postgres.ColumnList{IDColumn, CodeColumn, YearColumn, SemesterColumn, ProgrammeColumn, AuColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns  = postgres.ColumnList{CodeColumn, YearColumn, SemesterColumn, ProgrammeColumn, AuColumn, CreatedAtColumn, UpdatedAtColumn}
+		IDColumn                           = postgres.IntegerColumn("id")
+		CodeColumn                         = postgres.StringColumn("code")
+		YearColumn                         = postgres.IntegerColumn("year")
+		SemesterColumn                     = postgres.StringColumn("semester")
+		ProgrammeColumn                    = postgres.StringColumn("programme")
+		AuColumn                           = postgres.IntegerColumn("au")
+		CreatedAtColumn                    = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn                    = postgres.TimestampzColumn("updated_at")
+		LateThresholdMinutesColumn         = postgres.IntegerColumn("late_threshold_minutes")
+		AttendanceLockedAfterColumn        = postgres.TimestampzColumn("attendance_locked_after")
+		AttendanceOpensBeforeMinutesColumn = postgres.IntegerColumn("attendance_opens_before_minutes")
+		AttendanceClosesAfterMinutesColumn = postgres.IntegerColumn("attendance_closes_after_minutes")
+		allColumns                         = postgres.ColumnList{IDColumn, CodeColumn, YearColumn, SemesterColumn, ProgrammeColumn, AuColumn, CreatedAtColumn, UpdatedAtColumn, LateThresholdMinutesColumn, AttendanceLockedAfterColumn, AttendanceOpensBeforeMinutesColumn, AttendanceClosesAfterMinutesColumn}
+		mutableColumns                     = postgres.ColumnList{CodeColumn, YearColumn, SemesterColumn, ProgrammeColumn, AuColumn, CreatedAtColumn, UpdatedAtColumn, LateThresholdMinutesColumn, AttendanceLockedAfterColumn, AttendanceOpensBeforeMinutesColumn, AttendanceClosesAfterMinutesColumn}
 	)
 
 	return classesTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:        IDColumn,
-		Code:      CodeColumn,
-		Year:      YearColumn,
-		Semester:  SemesterColumn,
-		Programme: ProgrammeColumn,
-		Au:        AuColumn,
-		CreatedAt: CreatedAtColumn,
-		UpdatedAt: UpdatedAtColumn,
+		ID:                           IDColumn,
+		Code:                         CodeColumn,
+		Year:                         YearColumn,
+		Semester:                     SemesterColumn,
+		Programme:                    ProgrammeColumn,
+		Au:                           AuColumn,
+		CreatedAt:                    CreatedAtColumn,
+		UpdatedAt:                    UpdatedAtColumn,
+		LateThresholdMinutes:         LateThresholdMinutesColumn,
+		AttendanceLockedAfter:        AttendanceLockedAfterColumn,
+		AttendanceOpensBeforeMinutes: AttendanceOpensBeforeMinutesColumn,
+		AttendanceClosesAfterMinutes: AttendanceClosesAfterMinutesColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -17,12 +17,17 @@ type sessionEnrollmentsTable struct {
 	postgres.Table
 
 	// Columns
-	ID        postgres.ColumnInteger
-	SessionID postgres.ColumnInteger
-	UserID    postgres.ColumnString
-	Attended  postgres.ColumnBool
-	CreatedAt postgres.ColumnTimestampz
-	UpdatedAt postgres.ColumnTimestampz
+	ID                      postgres.ColumnInteger
+	SessionID               postgres.ColumnInteger
+	UserID                  postgres.ColumnString
+	CreatedAt               postgres.ColumnTimestampz
+	UpdatedAt               postgres.ColumnTimestampz
+	Status                  postgres.ColumnString
+	MarkedBy                postgres.ColumnString
+	CheckedInAt             postgres.ColumnTimestampz
+	Source                  postgres.ColumnString
+	SignatureFailedAttempts postgres.ColumnInteger
+	ReaderID                postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -63,26 +68,36 @@ func newSessionEnrollmentsTable(schemaName, tableName, alias string) *SessionEnr
 
 func newSessionEnrollmentsTableImpl(schemaName, tableName, alias string) sessionEnrollmentsTable {
 	var (
-		IDColumn        = postgres.IntegerColumn("id")
-		SessionIDColumn = postgres.IntegerColumn("session_id")
-		UserIDColumn    = postgres.StringColumn("user_id")
-		AttendedColumn  = postgres.BoolColumn("attended")
-		CreatedAtColumn = postgres.TimestampzColumn("created_at")
-		UpdatedAtColumn = postgres.TimestampzColumn("updated_at")
-		allColumns      = postgres.ColumnList{IDColumn, SessionIDColumn, UserIDColumn, AttendedColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns  = postgres.ColumnList{SessionIDColumn, UserIDColumn, AttendedColumn, CreatedAtColumn, UpdatedAtColumn}
+		IDColumn                      = postgres.IntegerColumn("id")
+		SessionIDColumn               = postgres.IntegerColumn("session_id")
+		UserIDColumn                  = postgres.StringColumn("user_id")
+		CreatedAtColumn               = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn               = postgres.TimestampzColumn("updated_at")
+		StatusColumn                  = postgres.StringColumn("status")
+		MarkedByColumn                = postgres.StringColumn("marked_by")
+		CheckedInAtColumn             = postgres.TimestampzColumn("checked_in_at")
+		SourceColumn                  = postgres.StringColumn("source")
+		SignatureFailedAttemptsColumn = postgres.IntegerColumn("signature_failed_attempts")
+		ReaderIDColumn                = postgres.StringColumn("reader_id")
+		allColumns                    = postgres.ColumnList{IDColumn, SessionIDColumn, UserIDColumn, CreatedAtColumn, UpdatedAtColumn, StatusColumn, MarkedByColumn, CheckedInAtColumn, SourceColumn, SignatureFailedAttemptsColumn, ReaderIDColumn}
+		mutableColumns                = postgres.ColumnList{SessionIDColumn, UserIDColumn, CreatedAtColumn, UpdatedAtColumn, StatusColumn, MarkedByColumn, CheckedInAtColumn, SourceColumn, SignatureFailedAttemptsColumn, ReaderIDColumn}
 	)
 
 	return sessionEnrollmentsTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:        IDColumn,
-		SessionID: SessionIDColumn,
-		UserID:    UserIDColumn,
-		Attended:  AttendedColumn,
-		CreatedAt: CreatedAtColumn,
-		UpdatedAt: UpdatedAtColumn,
+		ID:                      IDColumn,
+		SessionID:               SessionIDColumn,
+		UserID:                  UserIDColumn,
+		CreatedAt:               CreatedAtColumn,
+		UpdatedAt:               UpdatedAtColumn,
+		Status:                  StatusColumn,
+		MarkedBy:                MarkedByColumn,
+		CheckedInAt:             CheckedInAtColumn,
+		Source:                  SourceColumn,
+		SignatureFailedAttempts: SignatureFailedAttemptsColumn,
+		ReaderID:                ReaderIDColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -10,13 +10,27 @@ package table
 // UseSchema sets a new schema name for all generated table SQL builder types. It is recommended to invoke
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
+	AttendanceAmendments = AttendanceAmendments.FromSchema(schema)
+	ClassAttendanceRuleVersions = ClassAttendanceRuleVersions.FromSchema(schema)
 	ClassAttendanceRules = ClassAttendanceRules.FromSchema(schema)
 	ClassGroupManagers = ClassGroupManagers.FromSchema(schema)
 	ClassGroupSessions = ClassGroupSessions.FromSchema(schema)
 	ClassGroups = ClassGroups.FromSchema(schema)
+	ClassNotificationTemplates = ClassNotificationTemplates.FromSchema(schema)
 	Classes = Classes.FromSchema(schema)
+	ExcusalSessionEnrollments = ExcusalSessionEnrollments.FromSchema(schema)
+	Excusals = Excusals.FromSchema(schema)
+	GlobalAttendanceRules = GlobalAttendanceRules.FromSchema(schema)
+	InterventionRunMails = InterventionRunMails.FromSchema(schema)
+	InterventionRunRuleFailures = InterventionRunRuleFailures.FromSchema(schema)
+	InterventionRuns = InterventionRuns.FromSchema(schema)
+	RuleEvaluations = RuleEvaluations.FromSchema(schema)
+	RuleTemplates = RuleTemplates.FromSchema(schema)
+	ScheduledJobs = ScheduledJobs.FromSchema(schema)
 	SchemaMigrations = SchemaMigrations.FromSchema(schema)
 	SessionEnrollments = SessionEnrollments.FromSchema(schema)
+	SignatureLockouts = SignatureLockouts.FromSchema(schema)
+	UserCards = UserCards.FromSchema(schema)
 	UserSignatures = UserSignatures.FromSchema(schema)
 	Users = Users.FromSchema(schema)
 }
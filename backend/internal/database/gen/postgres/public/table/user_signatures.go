@@ -17,9 +17,12 @@ type userSignaturesTable struct {
 	postgres.Table
 
 	// Columns
-	UserID    postgres.ColumnString
-	Signature postgres.ColumnString
-	UpdatedAt postgres.ColumnTimestampz
+	UserID         postgres.ColumnString
+	Signature      postgres.ColumnString
+	UpdatedAt      postgres.ColumnTimestampz
+	FailedAttempts postgres.ColumnInteger
+	Lockouts       postgres.ColumnInteger
+	LockedUntil    postgres.ColumnTimestampz
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -60,20 +63,26 @@ func newUserSignaturesTable(schemaName, tableName, alias string) *UserSignatures
 
 func newUserSignaturesTableImpl(schemaName, tableName, alias string) userSignaturesTable {
 	var (
-		UserIDColumn    = postgres.StringColumn("user_id")
-		SignatureColumn = postgres.StringColumn("signature")
-		UpdatedAtColumn = postgres.TimestampzColumn("updated_at")
-		allColumns      = postgres.ColumnList{UserIDColumn, SignatureColumn, UpdatedAtColumn}
-		mutableColumns  = postgres.ColumnList{SignatureColumn, UpdatedAtColumn}
+		UserIDColumn         = postgres.StringColumn("user_id")
+		SignatureColumn      = postgres.StringColumn("signature")
+		UpdatedAtColumn      = postgres.TimestampzColumn("updated_at")
+		FailedAttemptsColumn = postgres.IntegerColumn("failed_attempts")
+		LockoutsColumn       = postgres.IntegerColumn("lockouts")
+		LockedUntilColumn    = postgres.TimestampzColumn("locked_until")
+		allColumns           = postgres.ColumnList{UserIDColumn, SignatureColumn, UpdatedAtColumn, FailedAttemptsColumn, LockoutsColumn, LockedUntilColumn}
+		mutableColumns       = postgres.ColumnList{SignatureColumn, UpdatedAtColumn, FailedAttemptsColumn, LockoutsColumn, LockedUntilColumn}
 	)
 
 	return userSignaturesTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		UserID:    UserIDColumn,
-		Signature: SignatureColumn,
-		UpdatedAt: UpdatedAtColumn,
+		UserID:         UserIDColumn,
+		Signature:      SignatureColumn,
+		UpdatedAt:      UpdatedAtColumn,
+		FailedAttempts: FailedAttemptsColumn,
+		Lockouts:       LockoutsColumn,
+		LockedUntil:    LockedUntilColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// GlobalRuleInfo includes information on a global rule and its creator.
+type GlobalRuleInfo struct {
+	model.GlobalAttendanceRule
+	CreatorName  string `alias:"user.name" json:"creator_name"`
+	CreatorEmail string `alias:"user.email" json:"creator_email"`
+}
+
+func (d *DB) GetGlobalRules(ctx context.Context) ([]GlobalRuleInfo, error) {
+	var res []GlobalRuleInfo
+
+	stmt := selectGlobalRuleFields().ORDER_BY(
+		GlobalAttendanceRules.Title,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// GetActiveGlobalRules gets the global rules that are evaluated in intervention runs.
+func (d *DB) GetActiveGlobalRules(ctx context.Context) ([]GlobalRuleInfo, error) {
+	var res []GlobalRuleInfo
+
+	stmt := selectGlobalRuleFields().WHERE(
+		GlobalAttendanceRules.Active.IS_TRUE(),
+	).ORDER_BY(
+		GlobalAttendanceRules.ID,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type CreateGlobalRuleParams struct {
+	CreatorID   string
+	Title       string
+	Description string
+	Rule        string
+	Env         rules.E
+}
+
+func (d *DB) CreateGlobalRule(ctx context.Context, arg CreateGlobalRuleParams) (model.GlobalAttendanceRule, error) {
+	var res model.GlobalAttendanceRule
+
+	envString, err := (&rules.Environment{Env: arg.Env}).Value()
+	if err != nil {
+		return res, err
+	}
+
+	stmt := GlobalAttendanceRules.INSERT(
+		GlobalAttendanceRules.CreatorID,
+		GlobalAttendanceRules.Title,
+		GlobalAttendanceRules.Description,
+		GlobalAttendanceRules.Rule,
+		GlobalAttendanceRules.Environment,
+	).VALUES(
+		arg.CreatorID,
+		arg.Title,
+		arg.Description,
+		arg.Rule,
+		Json(envString),
+	).RETURNING(
+		GlobalAttendanceRules.AllColumns,
+	)
+
+	err = stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type UpdateGlobalRuleParams struct {
+	Active *bool
+}
+
+func (d *DB) UpdateGlobalRule(ctx context.Context, id int64, arg UpdateGlobalRuleParams) (model.GlobalAttendanceRule, error) {
+	var res model.GlobalAttendanceRule
+
+	// Nothing to update, so the rule is returned as it is.
+	active := BoolExp(GlobalAttendanceRules.Active)
+	if arg.Active != nil {
+		active = Bool(*arg.Active)
+	}
+
+	stmt := GlobalAttendanceRules.UPDATE(
+		GlobalAttendanceRules.Active,
+	).SET(
+		active,
+	).WHERE(
+		GlobalAttendanceRules.ID.EQ(Int64(id)),
+	).RETURNING(
+		GlobalAttendanceRules.AllColumns,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func (d *DB) DeleteGlobalRule(ctx context.Context, id int64) error {
+	var res model.GlobalAttendanceRule
+
+	stmt := GlobalAttendanceRules.DELETE().WHERE(
+		GlobalAttendanceRules.ID.EQ(Int64(id)),
+	).RETURNING(
+		GlobalAttendanceRules.AllColumns,
+	)
+
+	return stmt.QueryContext(ctx, d.qe, &res)
+}
+
+func selectGlobalRuleFields() SelectStatement {
+	return SELECT(
+		GlobalAttendanceRules.AllColumns,
+		Users.Name,
+		Users.Email,
+	).FROM(
+		GlobalAttendanceRules.INNER_JOIN(
+			Users, Users.ID.EQ(GlobalAttendanceRules.CreatorID),
+		),
+	)
+}
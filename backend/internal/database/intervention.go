@@ -20,42 +20,17 @@ type RuleInfo struct {
 	ClassSemester string `alias:"class.semester"`
 }
 
-// Intervention gets all rules.Fact of classes which had class group sessions occurring today up to now.
-// In addition, all RuleInfo of these classes are also returned.
-func (d *DB) Intervention(ctx context.Context) ([]rules.Fact, []RuleInfo, error) {
-	var facts []rules.Fact
-
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+// Intervention gets all rules.Fact of classes which had class group sessions occurring on the day of the given time,
+// up to that time. In addition, all RuleInfo of the active rules of these classes are also returned. Quarantined rules
+// are excluded.
+func (d *DB) Intervention(ctx context.Context, at time.Time) ([]rules.Fact, []RuleInfo, error) {
+	startOfDay := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
 
 	classGroupSessionPredicate := ClassGroupSessions.StartTime.GT_EQ(TimestampzT(startOfDay)).AND(
-		ClassGroupSessions.EndTime.LT(TimestampzT(now)),
+		ClassGroupSessions.EndTime.LT(TimestampzT(at)),
 	)
 
-	stmt := SELECT(
-		Classes.ID,
-		Classes.Code,
-		Classes.Year,
-		Classes.Semester,
-		ClassGroups.ClassType,
-		ClassGroupSessions.StartTime,
-		ClassGroupSessions.EndTime,
-		ClassGroupSessions.Venue,
-		SessionEnrollments.UserID,
-		Users.Name,
-		Users.Email,
-		SessionEnrollments.Attended,
-	).FROM(
-		Classes.INNER_JOIN(
-			ClassGroups, ClassGroups.ClassID.EQ(Classes.ID),
-		).INNER_JOIN(
-			ClassGroupSessions, ClassGroupSessions.ClassGroupID.EQ(ClassGroups.ID),
-		).INNER_JOIN(
-			SessionEnrollments, SessionEnrollments.SessionID.EQ(ClassGroupSessions.ID),
-		).INNER_JOIN(
-			Users, Users.ID.EQ(SessionEnrollments.UserID),
-		),
-	).WHERE(
+	stmt := selectFactFields().WHERE(
 		Classes.ID.IN(
 			SELECT(
 				Classes.ID,
@@ -76,7 +51,7 @@ func (d *DB) Intervention(ctx context.Context) ([]rules.Fact, []RuleInfo, error)
 		ClassGroupSessions.EndTime,
 	)
 
-	err := stmt.QueryContext(ctx, d.qe, &facts)
+	facts, err := d.queryFacts(ctx, stmt)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -103,6 +78,8 @@ func (d *DB) Intervention(ctx context.Context) ([]rules.Fact, []RuleInfo, error)
 	).WHERE(
 		classGroupSessionPredicate.AND(
 			ClassAttendanceRules.Active.IS_TRUE(),
+		).AND(
+			ClassAttendanceRules.QuarantinedAt.IS_NULL(),
 		),
 	).ORDER_BY(
 		Classes.ID,
@@ -111,3 +88,230 @@ func (d *DB) Intervention(ctx context.Context) ([]rules.Fact, []RuleInfo, error)
 	err = stmt.QueryContext(ctx, d.qe, &ruleInfos)
 	return facts, ruleInfos, err
 }
+
+// ClassIntervention is similar to Intervention, but gets the rules.Fact and RuleInfo of one class, whether or not it
+// had class group sessions occurring on the day of the given time.
+func (d *DB) ClassIntervention(ctx context.Context, classId int64, at time.Time) ([]rules.Fact, []RuleInfo, error) {
+	stmt := selectFactFields().WHERE(
+		Classes.ID.EQ(Int64(classId)).AND(
+			ClassGroupSessions.EndTime.LT(TimestampzT(at)),
+		),
+	).ORDER_BY(
+		SessionEnrollments.UserID,
+		ClassGroupSessions.StartTime,
+		ClassGroupSessions.EndTime,
+	)
+
+	facts, err := d.queryFacts(ctx, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ruleInfos []RuleInfo
+
+	stmt = SELECT(
+		ClassAttendanceRules.AllColumns,
+		Users.Name,
+		Users.Email,
+		Classes.Code,
+		Classes.Year,
+		Classes.Semester,
+	).FROM(
+		ClassAttendanceRules.INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassAttendanceRules.ClassID),
+		).INNER_JOIN(
+			Users, Users.ID.EQ(ClassAttendanceRules.CreatorID),
+		),
+	).WHERE(
+		Classes.ID.EQ(Int64(classId)).AND(
+			ClassAttendanceRules.Active.IS_TRUE(),
+		).AND(
+			ClassAttendanceRules.QuarantinedAt.IS_NULL(),
+		),
+	).ORDER_BY(
+		ClassAttendanceRules.ID,
+	)
+
+	err = stmt.QueryContext(ctx, d.qe, &ruleInfos)
+	return facts, ruleInfos, err
+}
+
+// QuarantineRule quarantines a rule that failed to compile or run, so that it is skipped in intervention runs until
+// its definition is changed.
+func (d *DB) QuarantineRule(ctx context.Context, id int64, reason string) error {
+	var res model.ClassAttendanceRule
+
+	stmt := ClassAttendanceRules.UPDATE().SET(
+		ClassAttendanceRules.QuarantinedAt.SET(TimestampzExp(NOW())),
+		ClassAttendanceRules.QuarantineReason.SET(String(reason)),
+	).WHERE(
+		ClassAttendanceRules.ID.EQ(Int64(id)),
+	).RETURNING(
+		ClassAttendanceRules.AllColumns,
+	)
+
+	return stmt.QueryContext(ctx, d.qe, &res)
+}
+
+// RuleFailureCount is the number of intervention runs in a row that a rule failed in.
+type RuleFailureCount struct {
+	RuleID int64 `alias:"intervention_run_rule_failure.rule_id"`
+	Count  int   `alias:"intervention_run_rule_failure.count"`
+}
+
+// GetRuleFailureCounts gets the RuleFailureCount of the given rules, counting the runs that a rule failed in since it
+// was last evaluated or changed. Rules without such failures are not returned.
+func (d *DB) GetRuleFailureCounts(ctx context.Context, ruleIds []int64) ([]RuleFailureCount, error) {
+	var res []RuleFailureCount
+	if len(ruleIds) == 0 {
+		return res, nil
+	}
+
+	ids := make([]Expression, 0, len(ruleIds))
+	for _, id := range ruleIds {
+		ids = append(ids, Int64(id))
+	}
+
+	stmt := SELECT(
+		InterventionRunRuleFailures.RuleID,
+		COUNT(STAR).AS("intervention_run_rule_failure.count"),
+	).FROM(
+		InterventionRunRuleFailures.INNER_JOIN(
+			ClassAttendanceRules, ClassAttendanceRules.ID.EQ(InterventionRunRuleFailures.RuleID),
+		),
+	).WHERE(
+		InterventionRunRuleFailures.RuleID.IN(ids...).AND(
+			InterventionRunRuleFailures.CreatedAt.GT(ClassAttendanceRules.UpdatedAt),
+		).AND(
+			NOT(
+				EXISTS(
+					SELECT(
+						RuleEvaluations.ID,
+					).FROM(
+						RuleEvaluations,
+					).WHERE(
+						RuleEvaluations.RuleID.EQ(InterventionRunRuleFailures.RuleID).AND(
+							RuleEvaluations.RunID.GT(InterventionRunRuleFailures.RunID),
+						),
+					),
+				),
+			),
+		),
+	).GROUP_BY(
+		InterventionRunRuleFailures.RuleID,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// GetCoordinatingClassFacts gets all rules.Fact of a class for class group sessions that ended before a given time.
+func (d *DB) GetCoordinatingClassFacts(ctx context.Context, id int64, until time.Time) ([]rules.Fact, error) {
+	stmt := selectFactFields().WHERE(
+		coordinatingClassRLS(ctx).AND(
+			Classes.ID.EQ(Int64(id)),
+		).AND(
+			ClassGroupSessions.EndTime.LT(TimestampzT(until)),
+		),
+	).ORDER_BY(
+		SessionEnrollments.UserID,
+		ClassGroupSessions.StartTime,
+		ClassGroupSessions.EndTime,
+	)
+
+	return d.queryFacts(ctx, stmt)
+}
+
+// Term is the year and semester of a class.
+type Term struct {
+	Year     int32
+	Semester string
+}
+
+// GetUserTermFacts gets all rules.Fact of the given users in every class of the given terms, for class group sessions
+// that ended before a given time.
+func (d *DB) GetUserTermFacts(ctx context.Context, userIds []string, terms []Term, until time.Time) ([]rules.Fact, error) {
+	if len(userIds) == 0 || len(terms) == 0 {
+		return nil, nil
+	}
+
+	users := make([]Expression, 0, len(userIds))
+	for _, id := range userIds {
+		users = append(users, String(id))
+	}
+
+	termRows := make([]Expression, 0, len(terms))
+	for _, term := range terms {
+		termRows = append(termRows, ROW(Int32(term.Year), String(term.Semester)))
+	}
+
+	stmt := selectFactFields().WHERE(
+		SessionEnrollments.UserID.IN(users...).AND(
+			ROW(Classes.Year, Classes.Semester).IN(termRows...),
+		).AND(
+			ClassGroupSessions.EndTime.LT(TimestampzT(until)),
+		),
+	).ORDER_BY(
+		Classes.ID,
+		SessionEnrollments.UserID,
+		ClassGroupSessions.StartTime,
+		ClassGroupSessions.EndTime,
+	)
+
+	return d.queryFacts(ctx, stmt)
+}
+
+// queryFacts runs a statement built from selectFactFields and annotates the resulting facts.
+func (d *DB) queryFacts(ctx context.Context, stmt SelectStatement) ([]rules.Fact, error) {
+	var res []rules.Fact
+	if err := stmt.QueryContext(ctx, d.qe, &res); err != nil {
+		return nil, err
+	}
+
+	rules.AnnotateFacts(res)
+	return res, nil
+}
+
+func selectFactFields() SelectStatement {
+	firstClassGroups := ClassGroups.AS("first_class_group")
+	firstClassGroupSessions := ClassGroupSessions.AS("first_class_group_session")
+
+	return SELECT(
+		Classes.ID,
+		Classes.Code,
+		Classes.Year,
+		Classes.Semester,
+		TimestampzExp(
+			SELECT(
+				MIN(firstClassGroupSessions.StartTime),
+			).FROM(
+				firstClassGroupSessions.INNER_JOIN(
+					firstClassGroups, firstClassGroups.ID.EQ(firstClassGroupSessions.ClassGroupID),
+				),
+			).WHERE(
+				firstClassGroups.ClassID.EQ(Classes.ID),
+			),
+		).AS("class.start_time"),
+		ClassGroups.ID,
+		ClassGroups.Name,
+		ClassGroups.ClassType,
+		ClassGroupSessions.StartTime,
+		ClassGroupSessions.EndTime,
+		ClassGroupSessions.Venue,
+		SessionEnrollments.UserID,
+		Users.Name,
+		Users.Email,
+		SessionEnrollments.Status,
+		isAttended().AS("session_enrollment.attended"),
+	).FROM(
+		Classes.INNER_JOIN(
+			ClassGroups, ClassGroups.ClassID.EQ(Classes.ID),
+		).INNER_JOIN(
+			ClassGroupSessions, ClassGroupSessions.ClassGroupID.EQ(ClassGroups.ID),
+		).INNER_JOIN(
+			SessionEnrollments, SessionEnrollments.SessionID.EQ(ClassGroupSessions.ID),
+		).INNER_JOIN(
+			Users, Users.ID.EQ(SessionEnrollments.UserID),
+		),
+	)
+}
@@ -0,0 +1,252 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+const (
+	// interventionRunMailsInsertBatchSize is the maximum number of intervention run mails inserted in a single
+	// statement.
+	interventionRunMailsInsertBatchSize = 1000
+)
+
+// likePatternEscaper escapes the wildcards of a LIKE pattern.
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// InterventionRunInfo contains information on an intervention run and the user who triggered it, if it was triggered
+// manually.
+type InterventionRunInfo struct {
+	model.InterventionRun
+	TriggeredByName *string `alias:"user.name" json:"triggered_by_name"`
+}
+
+// GetInterventionRuns gets intervention runs. Runs are ordered from the latest unless a sort order is given.
+func (d *DB) GetInterventionRuns(ctx context.Context, params ListQueryParams) ([]InterventionRunInfo, error) {
+	var res []InterventionRunInfo
+
+	stmt := selectInterventionRunFields().ORDER_BY(
+		InterventionRuns.StartedAt.DESC(),
+		InterventionRuns.ID.DESC(),
+	)
+
+	stmt = params.setSorts(stmt)
+	stmt = params.setLimit(stmt)
+	stmt = params.setOffset(stmt)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func (d *DB) GetInterventionRun(ctx context.Context, id int64) (InterventionRunInfo, error) {
+	var res InterventionRunInfo
+
+	stmt := selectInterventionRunFields().WHERE(
+		InterventionRuns.ID.EQ(Int64(id)),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// GetInterventionRunRuleFailures gets the rules that failed in an intervention run.
+func (d *DB) GetInterventionRunRuleFailures(ctx context.Context, runId int64) ([]model.InterventionRunRuleFailure, error) {
+	var res []model.InterventionRunRuleFailure
+
+	stmt := SELECT(
+		InterventionRunRuleFailures.AllColumns,
+	).FROM(
+		InterventionRunRuleFailures,
+	).WHERE(
+		InterventionRunRuleFailures.RunID.EQ(Int64(runId)),
+	).ORDER_BY(
+		InterventionRunRuleFailures.ID,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// GetInterventionRunMails gets the mails sent in an intervention run. If a recipient is given, only mails with a To or
+// Cc address containing it are returned, ignoring case.
+func (d *DB) GetInterventionRunMails(ctx context.Context, runId int64, recipient *string) ([]model.InterventionRunMail, error) {
+	var res []model.InterventionRunMail
+
+	condition := InterventionRunMails.RunID.EQ(Int64(runId))
+	if recipient != nil {
+		pattern := LOWER(String("%" + likePatternEscaper.Replace(*recipient) + "%"))
+		condition = condition.AND(
+			LOWER(InterventionRunMails.ToAddresses).LIKE(pattern).OR(
+				LOWER(InterventionRunMails.CcAddresses).LIKE(pattern),
+			),
+		)
+	}
+
+	stmt := SELECT(
+		InterventionRunMails.AllColumns,
+	).FROM(
+		InterventionRunMails,
+	).WHERE(
+		condition,
+	).ORDER_BY(
+		InterventionRunMails.ID,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type CreateInterventionRunParams struct {
+	ScheduledFor time.Time
+	TriggeredBy  *string
+}
+
+// CreateInterventionRun records the start of an intervention run.
+func (d *DB) CreateInterventionRun(ctx context.Context, arg CreateInterventionRunParams) (model.InterventionRun, error) {
+	var res model.InterventionRun
+
+	stmt := InterventionRuns.INSERT(
+		InterventionRuns.ScheduledFor,
+		InterventionRuns.TriggeredBy,
+	).MODEL(
+		model.InterventionRun{
+			ScheduledFor: arg.ScheduledFor,
+			TriggeredBy:  arg.TriggeredBy,
+		},
+	).RETURNING(
+		InterventionRuns.AllColumns,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type EndInterventionRunParams struct {
+	NumFacts       int32
+	NumRules       int32
+	NumEvaluations int32
+	NumMails       int32
+	NumFailedMails int32
+	Error          *string
+}
+
+// EndInterventionRun records the end of an intervention run.
+func (d *DB) EndInterventionRun(ctx context.Context, id int64, arg EndInterventionRunParams) (model.InterventionRun, error) {
+	var res model.InterventionRun
+
+	errorExp := StringExp(NULL)
+	if arg.Error != nil {
+		errorExp = String(*arg.Error)
+	}
+
+	stmt := InterventionRuns.UPDATE().SET(
+		InterventionRuns.EndedAt.SET(TimestampzExp(NOW())),
+		InterventionRuns.Error.SET(errorExp),
+		InterventionRuns.NumFacts.SET(Int32(arg.NumFacts)),
+		InterventionRuns.NumRules.SET(Int32(arg.NumRules)),
+		InterventionRuns.NumEvaluations.SET(Int32(arg.NumEvaluations)),
+		InterventionRuns.NumMails.SET(Int32(arg.NumMails)),
+		InterventionRuns.NumFailedMails.SET(Int32(arg.NumFailedMails)),
+	).WHERE(
+		InterventionRuns.ID.EQ(Int64(id)),
+	).RETURNING(
+		InterventionRuns.AllColumns,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type CreateInterventionRunRuleFailureParams struct {
+	RuleID       *int64
+	GlobalRuleID *int64
+	RuleTitle    string
+	Reason       string
+}
+
+// CreateInterventionRunRuleFailures records the rules that failed in an intervention run.
+func (d *DB) CreateInterventionRunRuleFailures(ctx context.Context, runId int64, args []CreateInterventionRunRuleFailureParams) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	stmt := InterventionRunRuleFailures.INSERT(
+		InterventionRunRuleFailures.RunID,
+		InterventionRunRuleFailures.RuleID,
+		InterventionRunRuleFailures.GlobalRuleID,
+		InterventionRunRuleFailures.RuleTitle,
+		InterventionRunRuleFailures.Reason,
+	)
+
+	for _, arg := range args {
+		stmt = stmt.MODEL(
+			model.InterventionRunRuleFailure{
+				RunID:        runId,
+				RuleID:       arg.RuleID,
+				GlobalRuleID: arg.GlobalRuleID,
+				RuleTitle:    arg.RuleTitle,
+				Reason:       arg.Reason,
+			},
+		)
+	}
+
+	_, err := stmt.ExecContext(ctx, d.qe)
+	return err
+}
+
+type CreateInterventionRunMailParams struct {
+	ToAddresses string
+	CcAddresses string
+	Subject     string
+	Status      model.MailDeliveryStatus
+	Error       *string
+}
+
+// CreateInterventionRunMails records the delivery status of the mails sent in an intervention run.
+func (d *DB) CreateInterventionRunMails(ctx context.Context, runId int64, args []CreateInterventionRunMailParams) error {
+	for start := 0; start < len(args); start += interventionRunMailsInsertBatchSize {
+		stmt := InterventionRunMails.INSERT(
+			InterventionRunMails.RunID,
+			InterventionRunMails.ToAddresses,
+			InterventionRunMails.CcAddresses,
+			InterventionRunMails.Subject,
+			InterventionRunMails.Status,
+			InterventionRunMails.Error,
+		)
+
+		for _, arg := range args[start:min(start+interventionRunMailsInsertBatchSize, len(args))] {
+			stmt = stmt.MODEL(
+				model.InterventionRunMail{
+					RunID:       runId,
+					ToAddresses: arg.ToAddresses,
+					CcAddresses: arg.CcAddresses,
+					Subject:     arg.Subject,
+					Status:      arg.Status,
+					Error:       arg.Error,
+				},
+			)
+		}
+
+		if _, err := stmt.ExecContext(ctx, d.qe); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func selectInterventionRunFields() SelectStatement {
+	return SELECT(
+		InterventionRuns.AllColumns,
+		Users.Name,
+	).FROM(
+		InterventionRuns.LEFT_JOIN(
+			Users, Users.ID.EQ(InterventionRuns.TriggeredBy),
+		),
+	)
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// GetMyAttendance gets all rules.Fact of the current user, ordered by class and then by session. In addition, the
+// active rules of the classes that the user is enrolled in are also returned.
+func (d *DB) GetMyAttendance(ctx context.Context) ([]rules.Fact, []model.ClassAttendanceRule, error) {
+	userId := oauth2.GetAuthContext(ctx).User.ID
+
+	stmt := selectFactFields().WHERE(
+		SessionEnrollments.UserID.EQ(String(userId)),
+	).ORDER_BY(
+		Classes.ID,
+		ClassGroupSessions.StartTime,
+		ClassGroupSessions.EndTime,
+	)
+
+	facts, err := d.queryFacts(ctx, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var classRules []model.ClassAttendanceRule
+
+	stmt = SELECT(
+		ClassAttendanceRules.AllColumns,
+	).FROM(
+		ClassAttendanceRules,
+	).WHERE(
+		ClassAttendanceRules.Active.IS_TRUE().AND(
+			ClassAttendanceRules.QuarantinedAt.IS_NULL(),
+		).AND(
+			ClassAttendanceRules.ClassID.IN(
+				SELECT(
+					ClassGroups.ClassID,
+				).FROM(
+					ClassGroups.INNER_JOIN(
+						ClassGroupSessions, ClassGroupSessions.ClassGroupID.EQ(ClassGroups.ID),
+					).INNER_JOIN(
+						SessionEnrollments, SessionEnrollments.SessionID.EQ(ClassGroupSessions.ID),
+					),
+				).WHERE(
+					SessionEnrollments.UserID.EQ(String(userId)),
+				),
+			),
+		),
+	).ORDER_BY(
+		ClassAttendanceRules.ClassID,
+		ClassAttendanceRules.CreatedAt,
+	)
+
+	err = stmt.QueryContext(ctx, d.qe, &classRules)
+	return facts, classRules, err
+}
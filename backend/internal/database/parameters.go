@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -10,6 +11,18 @@ import (
 
 var decoder = schema.NewDecoder()
 
+// Nullable is an optional setting that can be cleared with an explicit null. Set is false if the setting is not given,
+// and Value is nil if the setting is cleared.
+type Nullable[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	return json.Unmarshal(data, &n.Value)
+}
+
 type ListQueryParams struct {
 	S       []string `schema:"sort"`
 	SParsed []SortParam
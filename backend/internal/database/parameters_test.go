@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"testing"
 
 	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
@@ -161,3 +162,39 @@ func Test_setOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestNullable_UnmarshalJSON(t *testing.T) {
+	tts := []struct {
+		name         string
+		withBody     string
+		wantNullable Nullable[int32]
+	}{
+		{
+			"with value",
+			`{"value": 5}`,
+			Nullable[int32]{true, to.Ptr(int32(5))},
+		},
+		{
+			"with explicit null",
+			`{"value": null}`,
+			Nullable[int32]{true, nil},
+		},
+		{
+			"with value not given",
+			`{}`,
+			Nullable[int32]{},
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			var body struct {
+				Value Nullable[int32] `json:"value"`
+			}
+			a.Nil(json.Unmarshal([]byte(tt.withBody), &body))
+			a.Equal(tt.wantNullable, body.Value)
+		})
+	}
+}
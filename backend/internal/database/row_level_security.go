@@ -122,3 +122,85 @@ func sessionEnrollmentRLS(ctx context.Context) BoolExpression {
 		SessionEnrollments.UserID.EQ(String(auth.User.ID)),
 	)
 }
+
+// excusalRLS scopes excusal entities to be shown only to users with appropriate privileges.
+// The following users have access within the relevant scopes:
+// 1. System Administrators have access to all records.
+// 2. Course Coordinators have access to excusals for classes they are coordinating.
+// 3. Normal users have access to their own excusals.
+func excusalRLS(ctx context.Context) BoolExpression {
+	auth := oauth2.GetAuthContext(ctx)
+
+	return Bool(
+		auth.User.Role == model.UserRole_SystemAdmin,
+	).OR(
+		Excusals.ClassID.IN(
+			SELECT(
+				ClassGroups.ClassID,
+			).FROM(
+				ClassGroups.INNER_JOIN(
+					ClassGroupManagers, ClassGroupManagers.ClassGroupID.EQ(ClassGroups.ID),
+				),
+			).WHERE(
+				ClassGroupManagers.UserID.EQ(String(auth.User.ID)).AND(
+					ClassGroupManagers.ManagingRole.EQ(ManagingRole.CourseCoordinator),
+				),
+			),
+		),
+	).OR(
+		Excusals.UserID.EQ(String(auth.User.ID)),
+	)
+}
+
+// attendanceAmendmentRLS scopes attendance amendment entities to be shown only to users with appropriate privileges.
+// The Classes table must be in scope. The following users have access within the relevant scopes:
+// 1. System Administrators have access to all records.
+// 2. Course Coordinators have access to amendments for classes they are coordinating.
+// 3. Normal users have access to amendments that they requested.
+func attendanceAmendmentRLS(ctx context.Context) BoolExpression {
+	auth := oauth2.GetAuthContext(ctx)
+
+	return coordinatingClassRLS(ctx).OR(
+		AttendanceAmendments.RequesterID.EQ(String(auth.User.ID)),
+	)
+}
+
+// ruleTemplateRLS scopes rule template entities to be shown only to users with appropriate privileges.
+// The following users have access within the relevant scopes:
+// 1. System Administrators have access to all records.
+// 2. Course Coordinators of any class have access to all records.
+func ruleTemplateRLS(ctx context.Context) BoolExpression {
+	auth := oauth2.GetAuthContext(ctx)
+
+	return Bool(
+		auth.User.Role == model.UserRole_SystemAdmin,
+	).OR(
+		EXISTS(
+			SELECT(
+				ClassGroupManagers.ID,
+			).FROM(
+				ClassGroupManagers,
+			).WHERE(
+				ClassGroupManagers.UserID.EQ(String(auth.User.ID)).AND(
+					ClassGroupManagers.ManagingRole.EQ(ManagingRole.CourseCoordinator),
+				),
+			),
+		),
+	)
+}
+
+// ruleTemplateOwnerRLS scopes rule template entities to be modified only by users with appropriate privileges.
+// The following users have access within the relevant scopes:
+// 1. System Administrators have access to all records.
+// 2. Course Coordinators have access to templates they created.
+func ruleTemplateOwnerRLS(ctx context.Context) BoolExpression {
+	auth := oauth2.GetAuthContext(ctx)
+
+	return Bool(
+		auth.User.Role == model.UserRole_SystemAdmin,
+	).OR(
+		ruleTemplateRLS(ctx).AND(
+			RuleTemplates.CreatorID.EQ(String(auth.User.ID)),
+		),
+	)
+}
@@ -0,0 +1,280 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+const (
+	// ruleEvaluationsInsertBatchSize is the maximum number of rule evaluations inserted or updated in a single
+	// statement.
+	ruleEvaluationsInsertBatchSize = 1000
+)
+
+// RuleEvaluationState is the result of the latest evaluation of a rule for a user, together with the last time the
+// user was notified of the rule and the number of times the user was notified of the rule in the current streak. The
+// streak starts after the latest evaluation in which the user did not trigger the rule.
+type RuleEvaluationState struct {
+	RuleID         int64      `alias:"rule_evaluation.rule_id"`
+	UserID         string     `alias:"rule_evaluation.user_id"`
+	Triggered      bool       `alias:"rule_evaluation.triggered"`
+	LastNotifiedAt *time.Time `alias:"rule_evaluation.last_notified_at"`
+	NotifiedCount  int        `alias:"rule_evaluation.notified_count"`
+}
+
+// GetRuleEvaluationStates gets the RuleEvaluationState of each user that the given rules were evaluated for.
+func (d *DB) GetRuleEvaluationStates(ctx context.Context, ruleIds []int64) ([]RuleEvaluationState, error) {
+	var res []RuleEvaluationState
+	if len(ruleIds) == 0 {
+		return res, nil
+	}
+
+	ids := make([]Expression, 0, len(ruleIds))
+	for _, id := range ruleIds {
+		ids = append(ids, Int64(id))
+	}
+
+	// The latest evaluation of each rule for each user is picked first, so that the notification state is only worked
+	// out once for each of them.
+	latestEvaluations := SELECT(
+		RuleEvaluations.RuleID,
+		RuleEvaluations.UserID,
+		RuleEvaluations.Triggered,
+	).DISTINCT(
+		RuleEvaluations.RuleID,
+		RuleEvaluations.UserID,
+	).FROM(
+		RuleEvaluations,
+	).WHERE(
+		RuleEvaluations.RuleID.IN(ids...),
+	).ORDER_BY(
+		RuleEvaluations.RuleID,
+		RuleEvaluations.UserID,
+		RuleEvaluations.CreatedAt.DESC(),
+		RuleEvaluations.ID.DESC(),
+	).AsTable("latest_rule_evaluation")
+
+	latestRuleId := RuleEvaluations.RuleID.From(latestEvaluations)
+	latestUserId := RuleEvaluations.UserID.From(latestEvaluations)
+	latestTriggered := RuleEvaluations.Triggered.From(latestEvaluations)
+
+	notifiedEvaluations := RuleEvaluations.AS("notified_rule_evaluation")
+	notifiedCondition := notifiedEvaluations.RuleID.EQ(latestRuleId).AND(
+		notifiedEvaluations.UserID.EQ(latestUserId),
+	).AND(
+		notifiedEvaluations.Notified.IS_TRUE(),
+	)
+
+	untriggeredEvaluations := RuleEvaluations.AS("untriggered_rule_evaluation")
+	streakCondition := notifiedCondition.AND(
+		NOT(
+			EXISTS(
+				SELECT(
+					untriggeredEvaluations.ID,
+				).FROM(
+					untriggeredEvaluations,
+				).WHERE(
+					untriggeredEvaluations.RuleID.EQ(latestRuleId).AND(
+						untriggeredEvaluations.UserID.EQ(latestUserId),
+					).AND(
+						untriggeredEvaluations.Triggered.IS_FALSE(),
+					).AND(
+						untriggeredEvaluations.CreatedAt.GT(notifiedEvaluations.CreatedAt),
+					),
+				),
+			),
+		),
+	)
+
+	stmt := SELECT(
+		latestRuleId.AS("rule_evaluation.rule_id"),
+		latestUserId.AS("rule_evaluation.user_id"),
+		latestTriggered.AS("rule_evaluation.triggered"),
+		TimestampzExp(
+			SELECT(
+				MAX(notifiedEvaluations.CreatedAt),
+			).FROM(
+				notifiedEvaluations,
+			).WHERE(
+				notifiedCondition,
+			),
+		).AS("rule_evaluation.last_notified_at"),
+		IntExp(
+			SELECT(
+				COUNT(STAR),
+			).FROM(
+				notifiedEvaluations,
+			).WHERE(
+				streakCondition,
+			),
+		).AS("rule_evaluation.notified_count"),
+	).FROM(
+		latestEvaluations,
+	).ORDER_BY(
+		latestRuleId,
+		latestUserId,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type CreateRuleEvaluationParams struct {
+	RuleID      int64
+	RuleVersion int32
+	UserID      string
+	Triggered   bool
+}
+
+// CreateRuleEvaluations records the results of evaluating rules in an intervention run. The evaluations are recorded
+// as not notified.
+func (d *DB) CreateRuleEvaluations(ctx context.Context, runId int64, args []CreateRuleEvaluationParams) error {
+	for start := 0; start < len(args); start += ruleEvaluationsInsertBatchSize {
+		stmt := RuleEvaluations.INSERT(
+			RuleEvaluations.RunID,
+			RuleEvaluations.RuleID,
+			RuleEvaluations.RuleVersion,
+			RuleEvaluations.UserID,
+			RuleEvaluations.Triggered,
+			RuleEvaluations.Notified,
+		)
+
+		for _, arg := range args[start:min(start+ruleEvaluationsInsertBatchSize, len(args))] {
+			stmt = stmt.MODEL(
+				model.RuleEvaluation{
+					RunID:       runId,
+					RuleID:      arg.RuleID,
+					RuleVersion: arg.RuleVersion,
+					UserID:      arg.UserID,
+					Triggered:   arg.Triggered,
+				},
+			)
+		}
+
+		if _, err := stmt.ExecContext(ctx, d.qe); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type MarkRuleEvaluationsNotifiedParams struct {
+	UserID  string
+	RuleIDs []int64
+}
+
+// MarkRuleEvaluationsNotified marks the evaluations of an intervention run that users were notified of. This should
+// only be done once the mail to the user is delivered.
+func (d *DB) MarkRuleEvaluationsNotified(ctx context.Context, runId int64, args []MarkRuleEvaluationsNotifiedParams) error {
+	var rows []Expression
+	for _, arg := range args {
+		for _, ruleId := range arg.RuleIDs {
+			rows = append(rows, ROW(String(arg.UserID), Int64(ruleId)))
+		}
+	}
+
+	for start := 0; start < len(rows); start += ruleEvaluationsInsertBatchSize {
+		stmt := RuleEvaluations.UPDATE().SET(
+			RuleEvaluations.Notified.SET(Bool(true)),
+		).WHERE(
+			RuleEvaluations.RunID.EQ(Int64(runId)).AND(
+				ROW(RuleEvaluations.UserID, RuleEvaluations.RuleID).IN(
+					rows[start:min(start+ruleEvaluationsInsertBatchSize, len(rows))]...,
+				),
+			),
+		)
+
+		if _, err := stmt.ExecContext(ctx, d.qe); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListRuleEvaluationsParams are the query parameters for listing rule evaluations. Evaluations can be filtered by
+// user and by rule.
+type ListRuleEvaluationsParams struct {
+	ListQueryParams
+	UserID *string `schema:"user_id"`
+	RuleID *int64  `schema:"rule_id"`
+}
+
+func DecodeListRuleEvaluationsParams(source map[string][]string) (ListRuleEvaluationsParams, error) {
+	var l ListRuleEvaluationsParams
+	err := decoder.Decode(&l, source)
+	if err != nil {
+		return l, err
+	}
+
+	for _, s := range l.S {
+		p, err := parseSortParam(s, RuleEvaluations.AllColumns)
+		if err != nil {
+			return l, err
+		}
+
+		l.SParsed = append(l.SParsed, p)
+	}
+
+	return l, err
+}
+
+type CoordinatingClassRuleEvaluation struct {
+	model.RuleEvaluation
+	RuleTitle string `alias:"class_attendance_rule_version.title" json:"rule_title"`
+	UserName  string `alias:"user.name" json:"user_name"`
+}
+
+// GetCoordinatingClassRuleEvaluations gets the evaluations of the rules of a class. The rule title of each evaluation
+// is the title of the rule version that produced it. Evaluations are ordered from the latest unless a sort order is
+// given.
+func (d *DB) GetCoordinatingClassRuleEvaluations(ctx context.Context, id int64, params ListRuleEvaluationsParams) ([]CoordinatingClassRuleEvaluation, error) {
+	var res []CoordinatingClassRuleEvaluation
+
+	condition := coordinatingClassRLS(ctx).AND(
+		Classes.ID.EQ(Int64(id)),
+	)
+
+	if params.UserID != nil {
+		condition = condition.AND(RuleEvaluations.UserID.EQ(String(*params.UserID)))
+	}
+
+	if params.RuleID != nil {
+		condition = condition.AND(RuleEvaluations.RuleID.EQ(Int64(*params.RuleID)))
+	}
+
+	stmt := SELECT(
+		RuleEvaluations.AllColumns,
+		ClassAttendanceRuleVersions.Title,
+		Users.Name,
+	).FROM(
+		RuleEvaluations.INNER_JOIN(
+			ClassAttendanceRules, ClassAttendanceRules.ID.EQ(RuleEvaluations.RuleID),
+		).INNER_JOIN(
+			ClassAttendanceRuleVersions, ClassAttendanceRuleVersions.RuleID.EQ(RuleEvaluations.RuleID).AND(
+				ClassAttendanceRuleVersions.Version.EQ(RuleEvaluations.RuleVersion),
+			),
+		).INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassAttendanceRules.ClassID),
+		).INNER_JOIN(
+			Users, Users.ID.EQ(RuleEvaluations.UserID),
+		),
+	).WHERE(
+		condition,
+	).ORDER_BY(
+		RuleEvaluations.CreatedAt.DESC(),
+		RuleEvaluations.ID.DESC(),
+	)
+
+	stmt = params.setSorts(stmt)
+	stmt = params.setLimit(stmt)
+	stmt = params.setOffset(stmt)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
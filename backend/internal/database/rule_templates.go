@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// RuleTemplate contains information on a rule template and its creator.
+type RuleTemplate struct {
+	model.RuleTemplate
+	CreatorName string `alias:"user.name" json:"creator_name"`
+}
+
+// RuleTemplateClass contains information on a class with a rule instantiated from a rule template.
+type RuleTemplateClass struct {
+	RuleID        int64  `alias:"class_attendance_rule.id" sql:"primary_key" json:"rule_id"`
+	RuleActive    bool   `alias:"class_attendance_rule.active" json:"rule_active"`
+	ClassID       int64  `alias:"class.id" json:"class_id"`
+	ClassCode     string `alias:"class.code" json:"class_code"`
+	ClassYear     int32  `alias:"class.year" json:"class_year"`
+	ClassSemester string `alias:"class.semester" json:"class_semester"`
+}
+
+func (d *DB) GetRuleTemplates(ctx context.Context) ([]RuleTemplate, error) {
+	var res []RuleTemplate
+
+	stmt := selectRuleTemplateFields().WHERE(
+		ruleTemplateRLS(ctx),
+	).ORDER_BY(
+		RuleTemplates.Title,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func (d *DB) GetRuleTemplate(ctx context.Context, id int64) (RuleTemplate, error) {
+	var res RuleTemplate
+
+	stmt := selectRuleTemplateFields().WHERE(
+		ruleTemplateRLS(ctx).AND(
+			RuleTemplates.ID.EQ(Int64(id)),
+		),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// GetRuleTemplateClasses gets the classes with rules instantiated from a rule template.
+func (d *DB) GetRuleTemplateClasses(ctx context.Context, id int64) ([]RuleTemplateClass, error) {
+	var res []RuleTemplateClass
+
+	stmt := SELECT(
+		ClassAttendanceRules.ID,
+		ClassAttendanceRules.Active,
+		Classes.ID,
+		Classes.Code,
+		Classes.Year,
+		Classes.Semester,
+	).FROM(
+		ClassAttendanceRules.INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassAttendanceRules.ClassID),
+		).INNER_JOIN(
+			RuleTemplates, RuleTemplates.ID.EQ(ClassAttendanceRules.TemplateID),
+		),
+	).WHERE(
+		ruleTemplateRLS(ctx).AND(
+			RuleTemplates.ID.EQ(Int64(id)),
+		),
+	).ORDER_BY(
+		Classes.Year.DESC(),
+		Classes.Semester.DESC(),
+		Classes.Code,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type CreateRuleTemplateParams struct {
+	CreatorID   string
+	Title       string
+	Description string
+	Definition  rules.RuleDefinition
+}
+
+// CreateRuleTemplate creates a rule template. Only system admins and course coordinators can create rule templates.
+func (d *DB) CreateRuleTemplate(ctx context.Context, arg CreateRuleTemplateParams) (model.RuleTemplate, error) {
+	var res model.RuleTemplate
+
+	definitionString, err := arg.Definition.Value()
+	if err != nil {
+		return res, err
+	}
+
+	stmt := RuleTemplates.INSERT(
+		RuleTemplates.CreatorID,
+		RuleTemplates.Title,
+		RuleTemplates.Description,
+		RuleTemplates.Definition,
+	).QUERY(
+		SELECT(
+			String(arg.CreatorID),
+			String(arg.Title),
+			String(arg.Description),
+			Json(definitionString),
+		).WHERE(
+			ruleTemplateRLS(ctx),
+		),
+	).RETURNING(
+		RuleTemplates.AllColumns,
+	)
+
+	err = stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type UpdateRuleTemplateParams struct {
+	Title       string
+	Description string
+	Definition  rules.RuleDefinition
+}
+
+// UpdateRuleTemplate replaces the title, description and definition of a rule template. Rules instantiated from the
+// template are not changed.
+func (d *DB) UpdateRuleTemplate(ctx context.Context, id int64, arg UpdateRuleTemplateParams) (model.RuleTemplate, error) {
+	var res model.RuleTemplate
+
+	definitionString, err := arg.Definition.Value()
+	if err != nil {
+		return res, err
+	}
+
+	stmt := RuleTemplates.UPDATE().SET(
+		RuleTemplates.Title.SET(String(arg.Title)),
+		RuleTemplates.Description.SET(String(arg.Description)),
+		RuleTemplates.Definition.SET(Json(definitionString)),
+	).WHERE(
+		ruleTemplateOwnerRLS(ctx).AND(
+			RuleTemplates.ID.EQ(Int64(id)),
+		),
+	).RETURNING(
+		RuleTemplates.AllColumns,
+	)
+
+	err = stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type PropagateRuleTemplateParams struct {
+	EditorID    string
+	Title       string
+	Description string
+	Rule        string
+	Env         rules.E
+}
+
+// PropagateRuleTemplate updates the rules instantiated from a rule template to match the template. Only rules of
+// classes that the current user coordinates are updated, unless the user is a system admin. A new version is created
+// for each updated rule. The number of updated rules is returned.
+func (d *DB) PropagateRuleTemplate(ctx context.Context, id int64, arg PropagateRuleTemplateParams) (int, error) {
+	var res []model.ClassAttendanceRule
+
+	envString, err := (&rules.Environment{Env: arg.Env}).Value()
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := ClassAttendanceRules.UPDATE().SET(
+		ClassAttendanceRules.Title.SET(String(arg.Title)),
+		ClassAttendanceRules.Description.SET(String(arg.Description)),
+		ClassAttendanceRules.Rule.SET(String(arg.Rule)),
+		ClassAttendanceRules.Environment.SET(Json(envString)),
+		ClassAttendanceRules.Version.SET(ClassAttendanceRules.Version.ADD(Int32(1))),
+		ClassAttendanceRules.QuarantinedAt.SET(TimestampzExp(NULL)),
+		ClassAttendanceRules.QuarantineReason.SET(StringExp(NULL)),
+	).WHERE(
+		ClassAttendanceRules.ClassID.IN(
+			SELECT(
+				Classes.ID,
+			).FROM(
+				Classes,
+			).WHERE(
+				coordinatingClassRLS(ctx),
+			),
+		).AND(
+			ClassAttendanceRules.TemplateID.EQ(Int64(id)),
+		),
+	).RETURNING(
+		ClassAttendanceRules.AllColumns,
+	)
+
+	if err = stmt.QueryContext(ctx, d.qe, &res); err != nil {
+		return 0, err
+	}
+
+	return len(res), d.createRuleVersions(ctx, arg.EditorID, res...)
+}
+
+// DeleteRuleTemplate deletes a rule template. Rules instantiated from the template are kept, but are no longer linked
+// to the template.
+func (d *DB) DeleteRuleTemplate(ctx context.Context, id int64) error {
+	var res model.RuleTemplate
+
+	stmt := RuleTemplates.DELETE().WHERE(
+		ruleTemplateOwnerRLS(ctx).AND(
+			RuleTemplates.ID.EQ(Int64(id)),
+		),
+	).RETURNING(
+		RuleTemplates.AllColumns,
+	)
+
+	return stmt.QueryContext(ctx, d.qe, &res)
+}
+
+func selectRuleTemplateFields() SelectStatement {
+	return SELECT(
+		RuleTemplates.AllColumns,
+		Users.Name,
+	).FROM(
+		RuleTemplates.INNER_JOIN(
+			Users, Users.ID.EQ(RuleTemplates.CreatorID),
+		),
+	)
+}
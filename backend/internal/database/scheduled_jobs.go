@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// AdvisoryLock is a session-level Postgres advisory lock. The lock is held on its own connection, so it is released if
+// the process holding it loses its connection to the database.
+type AdvisoryLock struct {
+	conn *sql.Conn
+	key  int64
+}
+
+// AdvisoryLockKey returns the advisory lock key for a name.
+func AdvisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// TryAdvisoryLock tries to acquire the advisory lock with the given key without waiting. If the lock is held by another
+// session, false is returned.
+func (d *DB) TryAdvisoryLock(ctx context.Context, key int64) (*AdvisoryLock, bool, error) {
+	var res struct {
+		Locked bool `alias:"locked"`
+	}
+
+	conn, err := d.Conn.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	stmt := SELECT(
+		RawBool("pg_try_advisory_lock(#key)", RawArgs{"#key": key}).AS("locked"),
+	)
+
+	if err = stmt.QueryContext(ctx, conn, &res); err != nil || !res.Locked {
+		_ = conn.Close()
+		return nil, false, err
+	}
+
+	return &AdvisoryLock{conn, key}, true, nil
+}
+
+// Release releases the advisory lock and its connection. If the lock cannot be released, the connection is discarded
+// instead of being returned to the pool, so that the session holding the lock is closed.
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	defer func() {
+		_ = l.conn.Close()
+	}()
+
+	stmt := SELECT(
+		RawBool("pg_advisory_unlock(#key)", RawArgs{"#key": l.key}),
+	)
+
+	_, err := stmt.ExecContext(ctx, l.conn)
+	if err != nil {
+		// Returning driver.ErrBadConn marks the connection as bad, so that it is closed instead of reused.
+		_ = l.conn.Raw(func(any) error {
+			return driver.ErrBadConn
+		})
+	}
+
+	return err
+}
+
+// GetScheduledJob gets the progress of a scheduled job.
+func (d *DB) GetScheduledJob(ctx context.Context, name string) (model.ScheduledJob, error) {
+	var res model.ScheduledJob
+
+	stmt := SELECT(
+		ScheduledJobs.AllColumns,
+	).FROM(
+		ScheduledJobs,
+	).WHERE(
+		ScheduledJobs.Name.EQ(String(name)),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// CreateScheduledJob records a scheduled job with the given latest scheduled time, unless the job is already recorded.
+// The recorded progress of the job is returned.
+func (d *DB) CreateScheduledJob(ctx context.Context, name string, lastScheduledAt time.Time) (model.ScheduledJob, error) {
+	stmt := ScheduledJobs.INSERT(
+		ScheduledJobs.Name,
+		ScheduledJobs.LastScheduledAt,
+	).VALUES(
+		name,
+		lastScheduledAt,
+	).ON_CONFLICT(
+		ScheduledJobs.Name,
+	).DO_NOTHING()
+
+	if _, err := stmt.ExecContext(ctx, d.qe); err != nil {
+		return model.ScheduledJob{}, err
+	}
+
+	return d.GetScheduledJob(ctx, name)
+}
+
+// UpsertScheduledJob records the latest scheduled time that a scheduled job was run for.
+func (d *DB) UpsertScheduledJob(ctx context.Context, name string, lastScheduledAt time.Time) (model.ScheduledJob, error) {
+	var res model.ScheduledJob
+
+	stmt := ScheduledJobs.INSERT(
+		ScheduledJobs.Name,
+		ScheduledJobs.LastScheduledAt,
+	).VALUES(
+		name,
+		lastScheduledAt,
+	).ON_CONFLICT(
+		ScheduledJobs.Name,
+	).DO_UPDATE(
+		SET(
+			ScheduledJobs.LastScheduledAt.SET(ScheduledJobs.EXCLUDED.LastScheduledAt),
+		),
+	).RETURNING(
+		ScheduledJobs.AllColumns,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
@@ -0,0 +1,14 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryLockKey(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(AdvisoryLockKey("intervention"), AdvisoryLockKey("intervention"))
+	a.NotEqual(AdvisoryLockKey("intervention"), AdvisoryLockKey("another"))
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	sessionEnrollmentChangesChannel = "session_enrollment_changes"
+)
+
+// ListenSessionEnrollmentChanges blocks and calls handle with the new attendance entry whenever the attendance of a
+// session enrollment changes. Changes made through any connection to the database are received. A change that cannot
+// be parsed is passed to handleErr and skipped. This function returns when the context is cancelled or the connection
+// is lost.
+func (d *DB) ListenSessionEnrollmentChanges(ctx context.Context, handle func(AttendanceEntry), handleErr func(error)) error {
+	conn, err := d.Conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	return conn.Raw(func(driverConn any) error {
+		pgxConn := driverConn.(*stdlib.Conn).Conn()
+
+		if _, err := pgxConn.Exec(ctx, fmt.Sprintf("LISTEN %s", pgx.Identifier{sessionEnrollmentChangesChannel}.Sanitize())); err != nil {
+			return err
+		}
+
+		for {
+			notification, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+
+			var entry AttendanceEntry
+			if err = json.Unmarshal([]byte(notification.Payload), &entry); err != nil {
+				handleErr(fmt.Errorf("could not parse session enrollment change: %w", err))
+				continue
+			}
+
+			handle(entry)
+		}
+	})
+}
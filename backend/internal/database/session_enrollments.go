@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/enum"
 	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
 	. "github.com/go-jet/jet/v2/postgres"
@@ -46,9 +47,9 @@ func (d *DB) GetSessionEnrollment(ctx context.Context, id int64) (model.SessionE
 }
 
 type CreateSessionEnrollmentParams struct {
-	SessionID int64  `json:"session_id"`
-	UserID    string `json:"user_id"`
-	Attended  bool   `json:"attended"`
+	SessionID int64                  `json:"session_id"`
+	UserID    string                 `json:"user_id"`
+	Status    model.AttendanceStatus `json:"status"`
 }
 
 func (d *DB) CreateSessionEnrollment(ctx context.Context, arg CreateSessionEnrollmentParams) (model.SessionEnrollment, error) {
@@ -57,12 +58,12 @@ func (d *DB) CreateSessionEnrollment(ctx context.Context, arg CreateSessionEnrol
 	stmt := SessionEnrollments.INSERT(
 		SessionEnrollments.SessionID,
 		SessionEnrollments.UserID,
-		SessionEnrollments.Attended,
+		SessionEnrollments.Status,
 	).MODEL(
 		model.SessionEnrollment{
 			SessionID: arg.SessionID,
 			UserID:    arg.UserID,
-			Attended:  arg.Attended,
+			Status:    arg.Status,
 		},
 	).RETURNING(
 		SessionEnrollments.AllColumns,
@@ -73,13 +74,13 @@ func (d *DB) CreateSessionEnrollment(ctx context.Context, arg CreateSessionEnrol
 }
 
 type UpsertSessionEnrollmentParams struct {
-	SessionID int64  `json:"session_id"`
-	UserID    string `json:"user_id"`
-	Attended  bool   `json:"attended"`
+	SessionID int64                  `json:"session_id"`
+	UserID    string                 `json:"user_id"`
+	Status    model.AttendanceStatus `json:"status"`
 }
 
 // BatchUpsertSessionEnrollments inserts a batch of session enrollments into the database. If the session enrollment
-// already exists, then nothing is done. Note that the attended field is ignored in this operation.
+// already exists, then nothing is done. Note that the status field is ignored in this operation.
 func (d *DB) BatchUpsertSessionEnrollments(ctx context.Context, args []UpsertSessionEnrollmentParams) ([]model.SessionEnrollment, error) {
 	if len(args) == 0 {
 		return nil, nil
@@ -94,7 +95,7 @@ func (d *DB) BatchUpsertSessionEnrollments(ctx context.Context, args []UpsertSes
 				inserts = append(inserts, model.SessionEnrollment{
 					SessionID: param.SessionID,
 					UserID:    param.UserID,
-					Attended:  param.Attended,
+					Status:    param.Status,
 				})
 			}
 		}
@@ -103,7 +104,7 @@ func (d *DB) BatchUpsertSessionEnrollments(ctx context.Context, args []UpsertSes
 	stmt := SessionEnrollments.INSERT(
 		SessionEnrollments.SessionID,
 		SessionEnrollments.UserID,
-		SessionEnrollments.Attended,
+		SessionEnrollments.Status,
 	).MODELS(
 		inserts,
 	).ON_CONFLICT().ON_CONSTRAINT(
@@ -120,3 +121,28 @@ func (d *DB) BatchUpsertSessionEnrollments(ctx context.Context, args []UpsertSes
 	err := stmt.QueryContext(ctx, d.qe, &res)
 	return res, err
 }
+
+// isAttended checks if a session enrollment has been marked as attended. A student is deemed to have attended a session
+// if they were present or late.
+func isAttended() BoolExpression {
+	return SessionEnrollments.Status.IN(AttendanceStatus.Present, AttendanceStatus.Late)
+}
+
+// attendanceStatus converts an attendance status into an expression that can be assigned to a status column.
+func attendanceStatus(status model.AttendanceStatus) StringExpression {
+	return StringExp(CAST(String(string(status))).AS("attendance_status"))
+}
+
+// checkedInAt returns the check-in time to record for a session enrollment that is marked with the given status. An
+// existing check-in time is kept so that re-marking an attended student does not lose when they first checked in.
+func checkedInAt(status StringExpression, at TimestampzExpression) TimestampzExpression {
+	return TimestampzExp(
+		CASE().WHEN(
+			status.IN(AttendanceStatus.Present, AttendanceStatus.Late),
+		).THEN(
+			COALESCE(SessionEnrollments.CheckedInAt, at),
+		).ELSE(
+			NULL,
+		),
+	)
+}
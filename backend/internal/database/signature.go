@@ -2,13 +2,59 @@ package database
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/alexedwards/argon2id"
 	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
 	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
 )
 
+const (
+	// maxEnrollmentSignatureAttempts is the number of consecutive failed signature attempts allowed for a session
+	// enrollment before the enrolled user is locked out.
+	maxEnrollmentSignatureAttempts = 3
+	// maxUserSignatureAttempts is the number of consecutive failed signature attempts allowed for a user across all
+	// session enrollments before the user is locked out.
+	maxUserSignatureAttempts = 5
+
+	signatureLockoutBaseDuration = time.Minute
+	signatureLockoutMaxDuration  = time.Hour
+)
+
+var (
+	ErrSignatureNotSet   = errors.New("database: user has not set a signature")
+	ErrSignatureMismatch = errors.New("database: signature does not match")
+	ErrSignatureLocked   = errors.New("database: signature verification is locked")
+)
+
+// HasUserSignature checks if a user has set a signature.
+func (d *DB) HasUserSignature(ctx context.Context, id string) (bool, error) {
+	var res model.UserSignature
+
+	stmt := SELECT(
+		UserSignatures.UserID,
+	).FROM(
+		UserSignatures,
+	).WHERE(
+		UserSignatures.UserID.EQ(String(id)),
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	switch {
+	case errors.Is(err, qrm.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdateUserSignature sets the signature of a user. Any failed attempts and lockout for the user are cleared.
 func (d *DB) UpdateUserSignature(ctx context.Context, id string, newSignature string) error {
 	hash, err := argon2id.CreateHash(newSignature, argon2id.DefaultParams)
 	if err != nil {
@@ -28,8 +74,180 @@ func (d *DB) UpdateUserSignature(ctx context.Context, id string, newSignature st
 	).DO_UPDATE(
 		SET(
 			UserSignatures.Signature.SET(UserSignatures.EXCLUDED.Signature),
+			UserSignatures.FailedAttempts.SET(Int(0)),
+			UserSignatures.Lockouts.SET(Int(0)),
+			UserSignatures.LockedUntil.SET(TimestampzExp(NULL)),
 		),
 	)
 	_, err = stmt.ExecContext(ctx, d.qe)
 	return err
 }
+
+// verifyAttendanceSignature verifies the signature given for a session enrollment against the signature of the
+// enrolled user. The session enrollment must belong to a managed upcoming class group session. Failed attempts are
+// recorded, and the enrolled user is locked out if there are too many of them.
+func (d *DB) verifyAttendanceSignature(ctx context.Context, arg UpdateAttendanceEntryParams) error {
+	var signature struct {
+		UserID      string     `alias:"session_enrollment.user_id"`
+		Signature   *string    `alias:"user_signature.signature"`
+		LockedUntil *time.Time `alias:"user_signature.locked_until"`
+	}
+
+	stmt := SELECT(
+		SessionEnrollments.UserID,
+		UserSignatures.Signature,
+		UserSignatures.LockedUntil,
+	).FROM(
+		SessionEnrollments.LEFT_JOIN(
+			UserSignatures, UserSignatures.UserID.EQ(SessionEnrollments.UserID),
+		),
+	).WHERE(
+		isManagedUpcomingSessionEnrollment(ctx, arg.ClassGroupSessionID).AND(
+			SessionEnrollments.ID.EQ(Int64(arg.SessionEnrollmentID)),
+		),
+	)
+
+	if err := stmt.QueryContext(ctx, d.qe, &signature); err != nil {
+		return err
+	}
+
+	switch {
+	case signature.Signature == nil:
+		return ErrSignatureNotSet
+	case signature.LockedUntil != nil && signature.LockedUntil.After(time.Now()):
+		return ErrSignatureLocked
+	}
+
+	match, err := argon2id.ComparePasswordAndHash(arg.UserSignature, *signature.Signature)
+	if err != nil {
+		return err
+	} else if !match {
+		return d.recordSignatureFailure(ctx, signature.UserID, arg.SessionEnrollmentID)
+	}
+
+	resetStmt := UserSignatures.UPDATE().SET(
+		UserSignatures.FailedAttempts.SET(Int(0)),
+		UserSignatures.Lockouts.SET(Int(0)),
+	).WHERE(
+		UserSignatures.UserID.EQ(String(signature.UserID)).AND(
+			UserSignatures.FailedAttempts.GT(Int(0)).OR(UserSignatures.Lockouts.GT(Int(0))),
+		),
+	)
+
+	_, err = resetStmt.ExecContext(ctx, d.qe)
+	return err
+}
+
+// recordSignatureFailure records a failed signature attempt for a session enrollment and its enrolled user. If either
+// has too many consecutive failed attempts, the user is locked out and the lockout is recorded. ErrSignatureLocked is
+// returned if the user is locked out, otherwise ErrSignatureMismatch is returned. The failed attempts of the session
+// enrollment are reset in the same transaction that locks the user out, so that the lockout is not triggered again by
+// the next failed attempt once it expires.
+func (d *DB) recordSignatureFailure(ctx context.Context, userId string, enrollmentId int64) error {
+	txDb, tx, err := d.AsTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = txDb.recordSignatureFailureTx(ctx, userId, enrollmentId); err != nil &&
+		!errors.Is(err, ErrSignatureMismatch) && !errors.Is(err, ErrSignatureLocked) {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return commitErr
+	}
+
+	return err
+}
+
+// recordSignatureFailureTx does the work of recordSignatureFailure, and should be run in a transaction.
+func (d *DB) recordSignatureFailureTx(ctx context.Context, userId string, enrollmentId int64) error {
+	var enrollment model.SessionEnrollment
+
+	enrollmentStmt := SessionEnrollments.UPDATE().SET(
+		SessionEnrollments.SignatureFailedAttempts.SET(SessionEnrollments.SignatureFailedAttempts.ADD(Int(1))),
+	).WHERE(
+		SessionEnrollments.ID.EQ(Int64(enrollmentId)),
+	).RETURNING(
+		SessionEnrollments.SignatureFailedAttempts,
+	)
+
+	if err := enrollmentStmt.QueryContext(ctx, d.qe, &enrollment); err != nil {
+		return err
+	}
+
+	var userSignature model.UserSignature
+
+	userStmt := UserSignatures.UPDATE().SET(
+		UserSignatures.FailedAttempts.SET(UserSignatures.FailedAttempts.ADD(Int(1))),
+	).WHERE(
+		UserSignatures.UserID.EQ(String(userId)),
+	).RETURNING(
+		UserSignatures.FailedAttempts,
+		UserSignatures.Lockouts,
+	)
+
+	if err := userStmt.QueryContext(ctx, d.qe, &userSignature); err != nil {
+		return err
+	}
+
+	if enrollment.SignatureFailedAttempts < maxEnrollmentSignatureAttempts &&
+		userSignature.FailedAttempts < maxUserSignatureAttempts {
+		return ErrSignatureMismatch
+	}
+
+	lockedUntil := time.Now().Add(signatureLockoutDuration(userSignature.Lockouts))
+
+	lockStmt := UserSignatures.UPDATE().SET(
+		UserSignatures.FailedAttempts.SET(Int(0)),
+		UserSignatures.Lockouts.SET(UserSignatures.Lockouts.ADD(Int(1))),
+		UserSignatures.LockedUntil.SET(TimestampzT(lockedUntil)),
+	).WHERE(
+		UserSignatures.UserID.EQ(String(userId)),
+	)
+
+	if _, err := lockStmt.ExecContext(ctx, d.qe); err != nil {
+		return err
+	}
+
+	resetStmt := SessionEnrollments.UPDATE().SET(
+		SessionEnrollments.SignatureFailedAttempts.SET(Int(0)),
+	).WHERE(
+		SessionEnrollments.ID.EQ(Int64(enrollmentId)),
+	)
+
+	if _, err := resetStmt.ExecContext(ctx, d.qe); err != nil {
+		return err
+	}
+
+	auditStmt := SignatureLockouts.INSERT(
+		SignatureLockouts.UserID,
+		SignatureLockouts.SessionEnrollmentID,
+		SignatureLockouts.TriggeredBy,
+		SignatureLockouts.LockedUntil,
+	).VALUES(
+		userId,
+		enrollmentId,
+		oauth2.GetAuthContext(ctx).User.ID,
+		lockedUntil,
+	)
+
+	if _, err := auditStmt.ExecContext(ctx, d.qe); err != nil {
+		return err
+	}
+
+	return ErrSignatureLocked
+}
+
+// signatureLockoutDuration returns how long a user is locked out for, given the number of consecutive lockouts before
+// this one. The duration doubles with each lockout, up to a maximum.
+func signatureLockoutDuration(lockouts int32) time.Duration {
+	duration := signatureLockoutBaseDuration
+	for i := int32(0); i < lockouts && duration < signatureLockoutMaxDuration; i++ {
+		duration *= 2
+	}
+
+	return min(duration, signatureLockoutMaxDuration)
+}
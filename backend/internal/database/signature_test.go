@@ -0,0 +1,50 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_signatureLockoutDuration(t *testing.T) {
+	tts := []struct {
+		name         string
+		withLockouts int32
+		wantDuration time.Duration
+	}{
+		{
+			"first lockout",
+			0,
+			time.Minute,
+		},
+		{
+			"second lockout",
+			1,
+			2 * time.Minute,
+		},
+		{
+			"fifth lockout",
+			4,
+			16 * time.Minute,
+		},
+		{
+			"lockout capped at maximum",
+			6,
+			time.Hour,
+		},
+		{
+			"many lockouts capped at maximum",
+			100,
+			time.Hour,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			a.Equal(tt.wantDuration, signatureLockoutDuration(tt.withLockouts))
+		})
+	}
+}
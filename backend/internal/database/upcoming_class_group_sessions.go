@@ -4,29 +4,26 @@ import (
 	"context"
 	"time"
 
-	"github.com/alexedwards/argon2id"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/enum"
 	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
 	"github.com/darylhjd/oams/backend/internal/oauth2"
 	. "github.com/go-jet/jet/v2/postgres"
-	"github.com/go-jet/jet/v2/qrm"
-)
-
-const (
-	attendanceTimeBuffer = time.Minute * 30
 )
 
 type UpcomingManagedClassGroupSession struct {
-	ID           int64               `alias:"class_group_session.id" json:"id"`
-	StartTime    time.Time           `alias:"class_group_session.start_time" json:"start_time"`
-	EndTime      time.Time           `alias:"class_group_session.end_time" json:"end_time"`
-	Venue        string              `alias:"class_group_session.venue" json:"venue"`
-	Code         string              `alias:"class.code" json:"code"`
-	Year         int32               `alias:"class.year" json:"year"`
-	Semester     string              `alias:"class.semester" json:"semester"`
-	Name         string              `alias:"class_group.name" json:"name"`
-	ClassType    model.ClassType     `alias:"class_group.class_type" json:"class_type"`
-	ManagingRole *model.ManagingRole `alias:"class_group_manager.managing_role" json:"managing_role"` // For nil values, exposed as system admin.
+	ID                 int64               `alias:"class_group_session.id" json:"id"`
+	StartTime          time.Time           `alias:"class_group_session.start_time" json:"start_time"`
+	EndTime            time.Time           `alias:"class_group_session.end_time" json:"end_time"`
+	AttendanceOpensAt  time.Time           `alias:"class_group_session.attendance_opens_at" json:"attendance_opens_at"`
+	AttendanceClosesAt time.Time           `alias:"class_group_session.attendance_closes_at" json:"attendance_closes_at"`
+	Venue              string              `alias:"class_group_session.venue" json:"venue"`
+	Code               string              `alias:"class.code" json:"code"`
+	Year               int32               `alias:"class.year" json:"year"`
+	Semester           string              `alias:"class.semester" json:"semester"`
+	Name               string              `alias:"class_group.name" json:"name"`
+	ClassType          model.ClassType     `alias:"class_group.class_type" json:"class_type"`
+	ManagingRole       *model.ManagingRole `alias:"class_group_manager.managing_role" json:"managing_role"` // For nil values, exposed as system admin.
 }
 
 func (d *DB) GetUpcomingManagedClassGroupSessions(ctx context.Context) ([]UpcomingManagedClassGroupSession, error) {
@@ -54,11 +51,15 @@ func (d *DB) GetUpcomingManagedClassGroupSession(ctx context.Context, id int64)
 }
 
 type AttendanceEntry struct {
-	ID        int64  `alias:"session_enrollment.id" json:"id"`
-	SessionID int64  `alias:"session_enrollment.session_id" json:"session_id"`
-	UserID    string `alias:"session_enrollment.user_id" json:"user_id"`
-	UserName  string `alias:"user.name" json:"user_name"`
-	Attended  bool   `alias:"session_enrollment.attended" json:"attended"`
+	ID          int64                  `alias:"session_enrollment.id" json:"id"`
+	SessionID   int64                  `alias:"session_enrollment.session_id" json:"session_id"`
+	UserID      string                 `alias:"session_enrollment.user_id" json:"user_id"`
+	UserName    string                 `alias:"user.name" json:"user_name"`
+	Status      model.AttendanceStatus `alias:"session_enrollment.status" json:"status"`
+	MarkedBy    *string                `alias:"session_enrollment.marked_by" json:"marked_by"`
+	CheckedInAt *time.Time             `alias:"session_enrollment.checked_in_at" json:"checked_in_at"`
+	Source      model.AttendanceSource `alias:"session_enrollment.source" json:"source"`
+	ReaderID    *string                `alias:"session_enrollment.reader_id" json:"reader_id"`
 }
 
 func (d *DB) GetUpcomingClassGroupAttendanceEntries(ctx context.Context, id int64) ([]AttendanceEntry, error) {
@@ -69,7 +70,11 @@ func (d *DB) GetUpcomingClassGroupAttendanceEntries(ctx context.Context, id int6
 		SessionEnrollments.SessionID,
 		SessionEnrollments.UserID,
 		Users.Name,
-		SessionEnrollments.Attended,
+		SessionEnrollments.Status,
+		SessionEnrollments.MarkedBy,
+		SessionEnrollments.CheckedInAt,
+		SessionEnrollments.Source,
+		SessionEnrollments.ReaderID,
 	).FROM(
 		SessionEnrollments.INNER_JOIN(
 			Users, Users.ID.EQ(SessionEnrollments.UserID),
@@ -99,71 +104,195 @@ func (d *DB) GetUpcomingClassGroupAttendanceEntries(ctx context.Context, id int6
 type UpdateAttendanceEntryParams struct {
 	ClassGroupSessionID int64
 	SessionEnrollmentID int64
-	Attended            bool
+	Status              model.AttendanceStatus
 	UserSignature       string
 }
 
+// UpdateAttendanceEntry updates the attendance of a session enrollment in a managed upcoming class group session. If
+// the current user is not an external service, the signature of the enrolled user is verified first.
 func (d *DB) UpdateAttendanceEntry(ctx context.Context, arg UpdateAttendanceEntryParams) (model.SessionEnrollment, error) {
 	var res model.SessionEnrollment
 
-	if oauth2.GetAuthContext(ctx).User.Role != model.UserRole_ExternalService {
-		var signature struct {
-			UserID    string  `alias:"session_enrollment.user_id"`
-			Signature *string `alias:"user_signature.signature"`
+	authContext := oauth2.GetAuthContext(ctx)
+
+	source := AttendanceSource.ExternalService
+	if authContext.User.Role != model.UserRole_ExternalService {
+		source = AttendanceSource.Signature
+
+		if err := d.verifyAttendanceSignature(ctx, arg); err != nil {
+			return res, err
 		}
+	}
 
-		signatureStmt := SELECT(
-			SessionEnrollments.UserID,
-			UserSignatures.Signature,
-		).FROM(
-			SessionEnrollments.LEFT_JOIN(
-				UserSignatures, UserSignatures.UserID.EQ(SessionEnrollments.UserID),
-			),
-		).WHERE(
+	status := attendanceStatus(arg.Status)
+	stmt := SessionEnrollments.UPDATE(
+		SessionEnrollments.Status,
+		SessionEnrollments.MarkedBy,
+		SessionEnrollments.CheckedInAt,
+		SessionEnrollments.Source,
+		SessionEnrollments.ReaderID,
+		SessionEnrollments.SignatureFailedAttempts,
+	).SET(
+		status,
+		String(authContext.User.ID),
+		checkedInAt(status, TimestampzT(time.Now())),
+		source,
+		StringExp(NULL),
+		Int(0),
+	).WHERE(
+		isManagedUpcomingSessionEnrollment(ctx, arg.ClassGroupSessionID).AND(
 			SessionEnrollments.ID.EQ(Int64(arg.SessionEnrollmentID)),
-		)
+		),
+	).RETURNING(
+		SessionEnrollments.AllColumns,
+	)
 
-		err := signatureStmt.QueryContext(ctx, d.qe, &signature)
-		if signature.Signature == nil {
-			sig, err := argon2id.CreateHash(signature.UserID, argon2id.DefaultParams)
-			if err != nil {
-				return res, err
-			}
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
 
-			signature.Signature = &sig
-		} else if err != nil {
-			return res, err
-		}
+type BulkAttendanceEntryParams struct {
+	SessionEnrollmentID int64                  `json:"session_enrollment_id"`
+	Status              model.AttendanceStatus `json:"status"`
+}
+
+type BulkUpdateAttendanceEntriesParams struct {
+	ClassGroupSessionID int64
+	// AllStatus, if set, is applied to every session enrollment of the class group session that is not in Entries.
+	AllStatus *model.AttendanceStatus
+	Entries   []BulkAttendanceEntryParams
+}
+
+type BulkAttendanceEntryResult struct {
+	SessionEnrollmentID int64                  `json:"session_enrollment_id"`
+	Status              model.AttendanceStatus `json:"status"`
+	Updated             bool                   `json:"updated"`
+}
 
-		match, err := argon2id.ComparePasswordAndHash(arg.UserSignature, *signature.Signature)
+// BulkUpdateAttendanceEntries updates the attendance of many session enrollments in a managed upcoming class group
+// session. Signatures are not verified, so the attendance is recorded as manually marked unless the current user is an
+// external service. A result is returned for each entry in the same order, followed by a result for each session
+// enrollment updated by AllStatus. Entries that could not be updated are not treated as an error.
+func (d *DB) BulkUpdateAttendanceEntries(ctx context.Context, arg BulkUpdateAttendanceEntriesParams) ([]BulkAttendanceEntryResult, error) {
+	res := make([]BulkAttendanceEntryResult, 0, len(arg.Entries))
+
+	source := AttendanceSource.Manual
+	if oauth2.GetAuthContext(ctx).User.Role == model.UserRole_ExternalService {
+		source = AttendanceSource.ExternalService
+	}
+
+	statusIds := map[model.AttendanceStatus][]Expression{}
+	entryIds := make([]Expression, 0, len(arg.Entries))
+	for _, entry := range arg.Entries {
+		statusIds[entry.Status] = append(statusIds[entry.Status], Int64(entry.SessionEnrollmentID))
+		entryIds = append(entryIds, Int64(entry.SessionEnrollmentID))
+	}
+
+	updated := map[int64]struct{}{}
+	for status, ids := range statusIds {
+		enrollments, err := d.bulkUpdateAttendanceEntries(ctx, arg.ClassGroupSessionID, status, source, SessionEnrollments.ID.IN(ids...))
 		if err != nil {
 			return res, err
-		} else if !match {
-			return res, qrm.ErrNoRows
 		}
+
+		for _, enrollment := range enrollments {
+			updated[enrollment.ID] = struct{}{}
+		}
+	}
+
+	for _, entry := range arg.Entries {
+		_, ok := updated[entry.SessionEnrollmentID]
+		res = append(res, BulkAttendanceEntryResult{entry.SessionEnrollmentID, entry.Status, ok})
+	}
+
+	if arg.AllStatus == nil {
+		return res, nil
+	}
+
+	rest := Bool(true)
+	if len(entryIds) > 0 {
+		rest = SessionEnrollments.ID.NOT_IN(entryIds...)
 	}
 
+	enrollments, err := d.bulkUpdateAttendanceEntries(ctx, arg.ClassGroupSessionID, *arg.AllStatus, source, rest)
+	if err != nil {
+		return res, err
+	}
+
+	for _, enrollment := range enrollments {
+		res = append(res, BulkAttendanceEntryResult{enrollment.ID, enrollment.Status, true})
+	}
+
+	return res, nil
+}
+
+func (d *DB) bulkUpdateAttendanceEntries(
+	ctx context.Context,
+	sessionId int64,
+	status model.AttendanceStatus,
+	source StringExpression,
+	predicate BoolExpression,
+) ([]model.SessionEnrollment, error) {
+	var res []model.SessionEnrollment
+
+	statusExp := attendanceStatus(status)
 	stmt := SessionEnrollments.UPDATE(
-		SessionEnrollments.Attended,
-	).MODEL(
-		model.SessionEnrollment{
-			Attended: arg.Attended,
-		},
+		SessionEnrollments.Status,
+		SessionEnrollments.MarkedBy,
+		SessionEnrollments.CheckedInAt,
+		SessionEnrollments.Source,
+		SessionEnrollments.ReaderID,
+	).SET(
+		statusExp,
+		String(oauth2.GetAuthContext(ctx).User.ID),
+		checkedInAt(statusExp, TimestampzT(time.Now())),
+		source,
+		StringExp(NULL),
 	).WHERE(
-		sessionEnrollmentRLS(ctx).AND(
-			SessionEnrollments.ID.EQ(Int64(arg.SessionEnrollmentID)),
+		isManagedUpcomingSessionEnrollment(ctx, sessionId).AND(
+			predicate,
+		),
+	).RETURNING(
+		SessionEnrollments.AllColumns,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+// CheckInAttendanceEntry marks the session enrollment of the current user for a class group session as present, or
+// late if the check-in is after the class lateness threshold. The class group session must be open for attendance
+// taking.
+func (d *DB) CheckInAttendanceEntry(ctx context.Context, sessionId int64) (model.SessionEnrollment, error) {
+	var res model.SessionEnrollment
+
+	now := time.Now()
+	status := checkInStatus(TimestampzT(now))
+	stmt := SessionEnrollments.UPDATE(
+		SessionEnrollments.Status,
+		SessionEnrollments.MarkedBy,
+		SessionEnrollments.CheckedInAt,
+		SessionEnrollments.Source,
+		SessionEnrollments.ReaderID,
+	).SET(
+		status,
+		String(oauth2.GetAuthContext(ctx).User.ID),
+		checkedInAt(status, TimestampzT(now)),
+		AttendanceSource.SelfCheckIn,
+		StringExp(NULL),
+	).FROM(
+		ClassGroupSessions.INNER_JOIN(
+			ClassGroups, ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
+		).INNER_JOIN(
+			Classes, Classes.ID.EQ(ClassGroups.ClassID),
+		),
+	).WHERE(
+		ClassGroupSessions.ID.EQ(SessionEnrollments.SessionID).AND(
+			SessionEnrollments.UserID.EQ(String(oauth2.GetAuthContext(ctx).User.ID)),
 		).AND(
-			SessionEnrollments.SessionID.IN(
-				SELECT(
-					ClassGroupSessions.ID,
-				).FROM(
-					ClassGroupSessions,
-				).WHERE(
-					isManagedUpcomingClassGroupSession(ctx).AND(
-						ClassGroupSessions.ID.EQ(Int64(arg.ClassGroupSessionID)),
-					),
-				),
-			),
+			isUpcomingClassGroupSession(),
+		).AND(
+			ClassGroupSessions.ID.EQ(Int64(sessionId)),
 		),
 	).RETURNING(
 		SessionEnrollments.AllColumns,
@@ -178,6 +307,8 @@ func selectUpcomingClassGroupSessionFields() SelectStatement {
 		ClassGroupSessions.ID,
 		ClassGroupSessions.StartTime,
 		ClassGroupSessions.EndTime,
+		attendanceOpensAt().AS("class_group_session.attendance_opens_at"),
+		attendanceClosesAt().AS("class_group_session.attendance_closes_at"),
 		ClassGroupSessions.Venue,
 		Classes.Code,
 		Classes.Year,
@@ -200,9 +331,84 @@ func selectUpcomingClassGroupSessionFields() SelectStatement {
 
 func isManagedUpcomingClassGroupSession(ctx context.Context) BoolExpression {
 	return managedClassGroupSessionRLS(ctx).AND(
-		TimestampzT(time.Now()).BETWEEN(
-			ClassGroupSessions.StartTime.SUB(INTERVALd(attendanceTimeBuffer)),
-			ClassGroupSessions.EndTime,
+		isUpcomingClassGroupSession(),
+	)
+}
+
+// isManagedUpcomingSessionEnrollment checks that a session enrollment belongs to a given managed upcoming class group
+// session.
+func isManagedUpcomingSessionEnrollment(ctx context.Context, sessionId int64) BoolExpression {
+	return sessionEnrollmentRLS(ctx).AND(
+		SessionEnrollments.SessionID.IN(
+			SELECT(
+				ClassGroupSessions.ID,
+			).FROM(
+				ClassGroupSessions,
+			).WHERE(
+				isManagedUpcomingClassGroupSession(ctx).AND(
+					ClassGroupSessions.ID.EQ(Int64(sessionId)),
+				),
+			),
+		),
+	)
+}
+
+// checkInStatus returns the attendance status for a student checking in at a given time. The status is late if the
+// check-in is after the class lateness threshold from the start of the session. The Classes and ClassGroupSessions
+// tables must be in scope.
+func checkInStatus(at TimestampzExpression) StringExpression {
+	return StringExp(CAST(
+		CASE().WHEN(
+			at.GT(ClassGroupSessions.StartTime.ADD(INTERVAL(1, MINUTE).MUL(Classes.LateThresholdMinutes))),
+		).THEN(
+			AttendanceStatus.Late,
+		).ELSE(
+			AttendanceStatus.Present,
+		),
+	).AS("attendance_status"))
+}
+
+// isUpcomingClassGroupSession checks that a class group session is currently open for attendance taking.
+func isUpcomingClassGroupSession() BoolExpression {
+	return isOpenClassGroupSessionAt(time.Now())
+}
+
+// isOpenClassGroupSessionAt checks that a class group session is open for attendance taking at a given time.
+func isOpenClassGroupSessionAt(at time.Time) BoolExpression {
+	return TimestampzT(at).BETWEEN(
+		attendanceOpensAt(),
+		attendanceClosesAt(),
+	)
+}
+
+// attendanceOpensAt returns when a class group session opens for attendance taking. The window of the class group is
+// used if set, otherwise the window of the class is used.
+func attendanceOpensAt() TimestampzExpression {
+	return ClassGroupSessions.StartTime.SUB(INTERVAL(1, MINUTE).MUL(
+		attendanceWindowMinutes(ClassGroups.AttendanceOpensBeforeMinutes, Classes.AttendanceOpensBeforeMinutes),
+	))
+}
+
+// attendanceClosesAt returns when a class group session closes for attendance taking. The window of the class group
+// is used if set, otherwise the window of the class is used.
+func attendanceClosesAt() TimestampzExpression {
+	return ClassGroupSessions.EndTime.ADD(INTERVAL(1, MINUTE).MUL(
+		attendanceWindowMinutes(ClassGroups.AttendanceClosesAfterMinutes, Classes.AttendanceClosesAfterMinutes),
+	))
+}
+
+// attendanceWindowMinutes looks up an attendance window offset for the class group of a class group session. Only the
+// ClassGroupSessions table needs to be in scope.
+func attendanceWindowMinutes(groupMinutes, classMinutes IntegerExpression) IntegerExpression {
+	return IntExp(
+		SELECT(
+			COALESCE(groupMinutes, classMinutes),
+		).FROM(
+			ClassGroups.INNER_JOIN(
+				Classes, Classes.ID.EQ(ClassGroups.ClassID),
+			),
+		).WHERE(
+			ClassGroups.ID.EQ(ClassGroupSessions.ClassGroupID),
 		),
 	)
 }
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	. "github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+func (d *DB) ListUserCards(ctx context.Context, params ListQueryParams) ([]model.UserCard, error) {
+	var res []model.UserCard
+
+	stmt := SELECT(
+		UserCards.AllColumns,
+	).FROM(
+		UserCards,
+	)
+
+	stmt = params.setSorts(stmt)
+	stmt = params.setLimit(stmt)
+	stmt = params.setOffset(stmt)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+type CreateUserCardParams struct {
+	CardUID string `json:"card_uid"`
+	UserID  string `json:"user_id"`
+}
+
+func (d *DB) CreateUserCard(ctx context.Context, arg CreateUserCardParams) (model.UserCard, error) {
+	var res model.UserCard
+
+	stmt := UserCards.INSERT(
+		UserCards.CardUID,
+		UserCards.UserID,
+	).MODEL(
+		model.UserCard{
+			CardUID: arg.CardUID,
+			UserID:  arg.UserID,
+		},
+	).RETURNING(
+		UserCards.AllColumns,
+	)
+
+	err := stmt.QueryContext(ctx, d.qe, &res)
+	return res, err
+}
+
+func (d *DB) DeleteUserCard(ctx context.Context, id int64) error {
+	var res model.UserCard
+
+	stmt := UserCards.DELETE().WHERE(
+		UserCards.ID.EQ(Int64(id)),
+	).RETURNING(
+		UserCards.AllColumns,
+	)
+
+	return stmt.QueryContext(ctx, d.qe, &res)
+}
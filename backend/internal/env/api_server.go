@@ -1,6 +1,9 @@
 package env
 
-import "os"
+import (
+	"fmt"
+	"os"
+)
 
 const (
 	apiServerPort              = "API_SERVER_PORT"
@@ -8,6 +11,13 @@ const (
 	apiServerAzureClientId     = "API_SERVER_AZURE_CLIENT_ID"
 	apiServerAzureClientSecret = "API_SERVER_AZURE_CLIENT_SECRET"
 	apiServerAzureLoginScope   = "API_SERVER_AZURE_LOGIN_SCOPE"
+	apiServerCheckInSecret     = "API_SERVER_CHECK_IN_SECRET"
+)
+
+const (
+	// minAPIServerCheckInSecretLength is the minimum length in bytes of the key that check-in tokens are signed with,
+	// which is the output size of HS256.
+	minAPIServerCheckInSecretLength = 32
 )
 
 // GetAPIServerPort returns the API_SERVER_PORT environment variable.
@@ -34,3 +44,17 @@ func GetAPIServerAzureClientSecret() string {
 func GetAPIServerAzureLoginScope() string {
 	return os.Getenv(apiServerAzureLoginScope)
 }
+
+// GetAPIServerCheckInSecret returns the API_SERVER_CHECK_IN_SECRET environment variable.
+func GetAPIServerCheckInSecret() string {
+	return os.Getenv(apiServerCheckInSecret)
+}
+
+// verifyAPIServerCheckInSecret checks that the check-in secret is long enough to sign check-in tokens with.
+func verifyAPIServerCheckInSecret() error {
+	if len(GetAPIServerCheckInSecret()) < minAPIServerCheckInSecretLength {
+		return fmt.Errorf("%s must be at least %d bytes long", apiServerCheckInSecret, minAPIServerCheckInSecretLength)
+	}
+
+	return nil
+}
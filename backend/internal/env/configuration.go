@@ -13,6 +13,7 @@ type Conf string
 const (
 	ConfAPIServer    Conf = "apiserver"
 	ConfIntervention Conf = "intervention"
+	ConfWorker       Conf = "worker"
 )
 
 // GetConfiguration returns the CONFIGURATION environment variable.
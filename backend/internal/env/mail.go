@@ -0,0 +1,97 @@
+package env
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	mailTransport = "MAIL_TRANSPORT"
+
+	smtpHost          = "SMTP_HOST"
+	smtpPort          = "SMTP_PORT"
+	smtpUsername      = "SMTP_USERNAME"
+	smtpPassword      = "SMTP_PASSWORD"
+	smtpSenderAddress = "SMTP_SENDER_ADDRESS"
+
+	mailSinkDirectory = "MAIL_SINK_DIRECTORY"
+)
+
+type MailTransport string
+
+const (
+	MailTransportAzure MailTransport = "azure"
+	MailTransportSMTP  MailTransport = "smtp"
+	MailTransportFile  MailTransport = "file"
+)
+
+// GetMailTransport returns the MAIL_TRANSPORT environment variable. Defaults to MailTransportAzure if not set.
+func GetMailTransport() MailTransport {
+	transport, ok := os.LookupEnv(mailTransport)
+	if !ok || transport == "" {
+		return MailTransportAzure
+	}
+
+	return MailTransport(transport)
+}
+
+// GetSMTPHost returns the SMTP_HOST environment variable.
+func GetSMTPHost() string {
+	return os.Getenv(smtpHost)
+}
+
+// GetSMTPPort returns the SMTP_PORT environment variable.
+func GetSMTPPort() string {
+	return os.Getenv(smtpPort)
+}
+
+// GetSMTPUsername returns the SMTP_USERNAME environment variable.
+func GetSMTPUsername() string {
+	return os.Getenv(smtpUsername)
+}
+
+// GetSMTPPassword returns the SMTP_PASSWORD environment variable.
+func GetSMTPPassword() string {
+	return os.Getenv(smtpPassword)
+}
+
+// GetSMTPSenderAddress returns the SMTP_SENDER_ADDRESS environment variable.
+func GetSMTPSenderAddress() string {
+	return os.Getenv(smtpSenderAddress)
+}
+
+// GetMailSinkDirectory returns the MAIL_SINK_DIRECTORY environment variable.
+func GetMailSinkDirectory() string {
+	return os.Getenv(mailSinkDirectory)
+}
+
+// mailTransportEnvs returns the environment variables required by the configured mail transport.
+func mailTransportEnvs() ([]string, error) {
+	switch transport := GetMailTransport(); transport {
+	case MailTransportAzure:
+		return []string{azureEmailEndpoint, azureEmailAccessKey, azureEmailSenderAddress}, nil
+	case MailTransportSMTP:
+		return []string{smtpHost, smtpPort, smtpSenderAddress}, nil
+	case MailTransportFile:
+		return []string{mailSinkDirectory}, nil
+	default:
+		return nil, fmt.Errorf("unknown %s value: %s", mailTransport, transport)
+	}
+}
+
+// IsMailConfigured checks if the environment variables required by the configured mail transport are set and not
+// empty.
+func IsMailConfigured() bool {
+	envs, err := mailTransportEnvs()
+	if err != nil {
+		return false
+	}
+
+	for _, env := range envs {
+		if os.Getenv(env) == "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,51 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/darylhjd/oams/backend/pkg/cron"
+)
+
+const (
+	schedulerInterventionCron = "SCHEDULER_INTERVENTION_CRON"
+	schedulerCatchUpWindow    = "SCHEDULER_CATCH_UP_WINDOW"
+)
+
+const (
+	defaultSchedulerCatchUpWindow = time.Hour * 24
+)
+
+// GetSchedulerInterventionCron returns the SCHEDULER_INTERVENTION_CRON environment variable.
+func GetSchedulerInterventionCron() string {
+	return os.Getenv(schedulerInterventionCron)
+}
+
+// GetSchedulerCatchUpWindow returns the SCHEDULER_CATCH_UP_WINDOW environment variable. Defaults to 24 hours if not
+// set.
+func GetSchedulerCatchUpWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv(schedulerCatchUpWindow))
+	if err != nil {
+		return defaultSchedulerCatchUpWindow
+	}
+
+	return window
+}
+
+// verifyScheduler checks that the scheduler environment variables are valid if they are set.
+func verifyScheduler() error {
+	if expr := GetSchedulerInterventionCron(); expr != "" {
+		if _, err := cron.Parse(expr); err != nil {
+			return fmt.Errorf("invalid %s value: %w", schedulerInterventionCron, err)
+		}
+	}
+
+	if window := os.Getenv(schedulerCatchUpWindow); window != "" {
+		if d, err := time.ParseDuration(window); err != nil || d < 0 {
+			return fmt.Errorf("invalid %s value: %s", schedulerCatchUpWindow, window)
+		}
+	}
+
+	return nil
+}
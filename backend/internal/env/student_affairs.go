@@ -0,0 +1,12 @@
+package env
+
+import "os"
+
+const (
+	studentAffairsEmail = "STUDENT_AFFAIRS_EMAIL"
+)
+
+// GetStudentAffairsEmail returns the STUDENT_AFFAIRS_EMAIL environment variable.
+func GetStudentAffairsEmail() string {
+	return os.Getenv(studentAffairsEmail)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 )
 
 func verifyEnvironment() error {
@@ -29,6 +30,7 @@ func verifyConfiguration() error {
 			apiServerAzureClientId,
 			apiServerAzureClientSecret,
 			apiServerAzureLoginScope,
+			apiServerCheckInSecret,
 			webServer,
 			databaseType,
 			databaseName,
@@ -38,6 +40,16 @@ func verifyConfiguration() error {
 			databasePort,
 			databaseSslMode,
 		}
+
+		// The API server only sends mail if it also runs the scheduler.
+		if GetSchedulerInterventionCron() != "" {
+			mailEnvs, err := mailTransportEnvs()
+			if err != nil {
+				return err
+			}
+
+			envs = append(envs, mailEnvs...)
+		}
 	case ConfIntervention:
 		envs = []string{
 			databaseType,
@@ -47,10 +59,32 @@ func verifyConfiguration() error {
 			databaseHost,
 			databasePort,
 			databaseSslMode,
-			azureEmailEndpoint,
-			azureEmailAccessKey,
-			azureEmailSenderAddress,
 		}
+
+		mailEnvs, err := mailTransportEnvs()
+		if err != nil {
+			return err
+		}
+
+		envs = append(envs, mailEnvs...)
+	case ConfWorker:
+		envs = []string{
+			databaseType,
+			databaseName,
+			databaseUser,
+			databasePassword,
+			databaseHost,
+			databasePort,
+			databaseSslMode,
+			schedulerInterventionCron,
+		}
+
+		mailEnvs, err := mailTransportEnvs()
+		if err != nil {
+			return err
+		}
+
+		envs = append(envs, mailEnvs...)
 	default:
 		envs = []string{
 			apiServerPort,
@@ -58,6 +92,7 @@ func verifyConfiguration() error {
 			apiServerAzureClientId,
 			apiServerAzureClientSecret,
 			apiServerAzureLoginScope,
+			apiServerCheckInSecret,
 			webServer,
 			databaseType,
 			databaseName,
@@ -66,20 +101,34 @@ func verifyConfiguration() error {
 			databaseHost,
 			databasePort,
 			databaseSslMode,
-			azureEmailEndpoint,
-			azureEmailAccessKey,
-			azureEmailSenderAddress,
 		}
+
+		mailEnvs, err := mailTransportEnvs()
+		if err != nil {
+			return err
+		}
+
+		envs = append(envs, mailEnvs...)
 	}
 
 	if err := checkEnvsNotEmpty(envs...); err != nil {
 		return err
 	}
 
+	if slices.Contains(envs, apiServerCheckInSecret) {
+		if err := verifyAPIServerCheckInSecret(); err != nil {
+			return err
+		}
+	}
+
 	if err := verifyDatabaseSsl(); err != nil {
 		return err
 	}
 
+	if err := verifyScheduler(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
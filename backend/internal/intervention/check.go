@@ -1,24 +1,42 @@
 package intervention
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/darylhjd/oams/backend/internal/database"
-	"github.com/expr-lang/expr"
+	"github.com/darylhjd/oams/backend/internal/rules"
 	"go.uber.org/zap"
 )
 
-// userFailedRules contains lists of database.RuleInfo for each user.
-// The list consists of database.RuleInfo that each user broke.
-type userFailedRules map[userKey][]database.RuleInfo
+// ruleEvaluation is the result of evaluating a rule against a user.
+type ruleEvaluation struct {
+	Rule      database.RuleInfo
+	User      userKey
+	Triggered bool
+}
 
-// ruleCreatorRuleFailedUsers contains lists of ruleAndFailedUsers for each rule creator.
-// The list consists of ruleAndFailedUsers, of which the rule belongs to the creator.
-type ruleCreatorRuleFailedUsers map[userKey][]ruleAndFailedUsers
+// failedRule is a rule that failed to compile or run, together with the reason for the failure. A failure is transient
+// if the rule only exceeded a limit while running, which may not happen again in a later run.
+type failedRule struct {
+	Rule      database.RuleInfo
+	Reason    string
+	Transient bool
+}
 
-func (s *Service) performChecks(fGroup factGrouping, rGroup ruleGrouping) (userFailedRules, ruleCreatorRuleFailedUsers, error) {
-	userRules := userFailedRules{}
-	ruleCreatorUsers := ruleCreatorRuleFailedUsers{}
+// quarantinedRule is a rule that is quarantined, together with the reason for the failure.
+type quarantinedRule struct {
+	Rule   database.RuleInfo
+	Reason string
+}
+
+// performChecks evaluates the rules of each class against the facts of the class. A rule that fails to compile or
+// run does not fail the checks of other rules, and none of its evaluations are returned.
+func (s *Service) performChecks(fGroup factGrouping, rGroup ruleGrouping) ([]ruleEvaluation, []failedRule) {
+	var (
+		evaluations []ruleEvaluation
+		failures    []failedRule
+	)
 
 	for classId, group := range fGroup {
 		for _, rule := range rGroup[classId] {
@@ -28,29 +46,63 @@ func (s *Service) performChecks(fGroup factGrouping, rGroup ruleGrouping) (userF
 				zap.Int64("rule_id", rule.ID),
 			)
 
-			prg, err := expr.Compile(rule.Rule, expr.AsBool(), expr.Env(rule.Environment.Env))
+			failedUsers, err := checkRule(rule, group)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to compile rule with id %d: %w", rule.ID, err)
+				s.l.Warn(
+					fmt.Sprintf("%s - rule failed", Namespace),
+					zap.Int64("class_id", classId),
+					zap.Int64("rule_id", rule.ID),
+					zap.Error(err),
+				)
+
+				var limitErr *rules.LimitError
+				failures = append(failures, failedRule{rule, err.Error(), errors.As(err, &limitErr)})
+				continue
 			}
 
-			ruleFailedUsers := ruleAndFailedUsers{Rule: rule}
-			for user, facts := range group {
-				runEnv := rule.Environment.Env.SetFacts(facts)
-
-				res, err := expr.Run(prg, runEnv)
-				switch {
-				case err != nil:
-					return nil, nil, fmt.Errorf("failed to run rule with id %d: %w", rule.ID, err)
-				case res.(bool):
-					userRules[user] = append(userRules[user], rule)
-					ruleFailedUsers.FailedUsers = append(ruleFailedUsers.FailedUsers, user)
-				}
+			failed := make(map[userKey]bool, len(failedUsers))
+			for _, user := range failedUsers {
+				failed[user] = true
 			}
 
-			creatorKey := userKey{rule.CreatorID, rule.CreatorName, rule.CreatorEmail}
-			ruleCreatorUsers[creatorKey] = append(ruleCreatorUsers[creatorKey], ruleFailedUsers)
+			for user := range group {
+				evaluations = append(evaluations, ruleEvaluation{rule, user, failed[user]})
+			}
+		}
+	}
+
+	return evaluations, failures
+}
+
+// checkRule compiles a rule and runs it against the facts of each user in a class.
+func checkRule(rule database.RuleInfo, group map[userKey][]rules.Fact) ([]userKey, error) {
+	prg, err := rules.Compile(rule.Rule, rule.Environment.Env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile rule: %w", err)
+	}
+
+	failedUsers, err := evaluateRule(prg, rule.Environment.Env, group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run rule: %w", err)
+	}
+
+	return failedUsers, nil
+}
+
+// evaluateRule runs a compiled rule against the facts of each user in a class, and returns the users who trigger the
+// rule. Excused sessions are excluded from the facts before the rule is run.
+func evaluateRule(prg *rules.Program, env rules.E, group map[userKey][]rules.Fact) ([]userKey, error) {
+	var failedUsers []userKey
+
+	for user, facts := range group {
+		triggered, err := prg.Run(env.SetFacts(rules.ExcludeExcused(facts)))
+		switch {
+		case err != nil:
+			return nil, err
+		case triggered:
+			failedUsers = append(failedUsers, user)
 		}
 	}
 
-	return userRules, ruleCreatorUsers, nil
+	return failedUsers, nil
 }
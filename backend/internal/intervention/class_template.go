@@ -0,0 +1,290 @@
+package intervention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"go.uber.org/zap"
+)
+
+const (
+	sampleUserId    = "SAMPLE001"
+	sampleUserName  = "Sample Student"
+	sampleUserEmail = "sample.student@example.com"
+
+	sampleCreatorId    = "SAMPLE002"
+	sampleCreatorName  = "Sample Coordinator"
+	sampleCreatorEmail = "sample.coordinator@example.com"
+
+	sampleRuleTitle       = "Sample Rule"
+	sampleRuleDescription = "This is a sample rule."
+
+	sampleClassCode     = "SC1001"
+	sampleClassYear     = 2024
+	sampleClassSemester = "1"
+)
+
+// ClassTemplateParams contains the notification template overrides of a class. A nil template means the built-in
+// template is used. The user templates replace the built-in user templates of every rule severity.
+type ClassTemplateParams struct {
+	UserTextTemplate        *string `json:"user_text_template"`
+	UserHtmlTemplate        *string `json:"user_html_template"`
+	RuleCreatorTextTemplate *string `json:"rule_creator_text_template"`
+	RuleCreatorHtmlTemplate *string `json:"rule_creator_html_template"`
+}
+
+// Verify checks that the given templates can be parsed, and that they can be rendered for a sample student who failed a
+// sample rule of a sample class.
+func (p ClassTemplateParams) Verify() error {
+	t, err := p.parse()
+	if err != nil {
+		return err
+	}
+
+	_, err = t.preview(database.CoordinatingClass{
+		Code:     sampleClassCode,
+		Year:     sampleClassYear,
+		Semester: sampleClassSemester,
+	}, nil)
+	return err
+}
+
+// parse parses the given templates. Templates that are not given are left nil.
+func (p ClassTemplateParams) parse() (*classTemplate, error) {
+	var (
+		t   classTemplate
+		err error
+	)
+
+	for _, text := range []*string{p.UserTextTemplate, p.UserHtmlTemplate, p.RuleCreatorTextTemplate, p.RuleCreatorHtmlTemplate} {
+		if text != nil && strings.TrimSpace(*text) == "" {
+			return nil, errors.New("template cannot be empty")
+		}
+	}
+
+	if p.UserTextTemplate != nil {
+		if t.userText, err = texttemplate.New("user_text").Parse(*p.UserTextTemplate); err != nil {
+			return nil, fmt.Errorf("invalid user text template: %w", err)
+		}
+	}
+
+	if p.UserHtmlTemplate != nil {
+		if t.userHtml, err = htmltemplate.New("user_html").Parse(*p.UserHtmlTemplate); err != nil {
+			return nil, fmt.Errorf("invalid user html template: %w", err)
+		}
+	}
+
+	if p.RuleCreatorTextTemplate != nil {
+		if t.ruleCreatorText, err = texttemplate.New("rule_creator_text").Parse(*p.RuleCreatorTextTemplate); err != nil {
+			return nil, fmt.Errorf("invalid rule creator text template: %w", err)
+		}
+	}
+
+	if p.RuleCreatorHtmlTemplate != nil {
+		if t.ruleCreatorHtml, err = htmltemplate.New("rule_creator_html").Parse(*p.RuleCreatorHtmlTemplate); err != nil {
+			return nil, fmt.Errorf("invalid rule creator html template: %w", err)
+		}
+	}
+
+	return &t, nil
+}
+
+// classTemplate contains the parsed notification template overrides of a class. A nil template means the built-in
+// template is used.
+type classTemplate struct {
+	userText        *texttemplate.Template
+	userHtml        *htmltemplate.Template
+	ruleCreatorText *texttemplate.Template
+	ruleCreatorHtml *htmltemplate.Template
+}
+
+// classTemplates contains the notification template overrides of each class that has them.
+type classTemplates map[int64]*classTemplate
+
+// userTemplateClass returns the class whose user templates are used for a rule, or zero if the built-in templates are
+// used.
+func (t classTemplates) userTemplateClass(classId int64) int64 {
+	if ct, ok := t[classId]; ok && (ct.userText != nil || ct.userHtml != nil) {
+		return classId
+	}
+
+	return 0
+}
+
+// ruleCreatorTemplateClass returns the class whose rule creator templates are used for a rule, or zero if the built-in
+// templates are used.
+func (t classTemplates) ruleCreatorTemplateClass(classId int64) int64 {
+	if ct, ok := t[classId]; ok && (ct.ruleCreatorText != nil || ct.ruleCreatorHtml != nil) {
+		return classId
+	}
+
+	return 0
+}
+
+// getClassTemplates gets and parses the notification template overrides of the classes of the given rules. Overrides
+// that cannot be parsed are skipped, so that the built-in templates are used for them.
+func (s *Service) getClassTemplates(ctx context.Context, ruleInfos []database.RuleInfo) (classTemplates, error) {
+	seen := map[int64]struct{}{}
+	classIds := make([]int64, 0, len(ruleInfos))
+	for _, rule := range ruleInfos {
+		if _, ok := seen[rule.ClassID]; !ok {
+			seen[rule.ClassID] = struct{}{}
+			classIds = append(classIds, rule.ClassID)
+		}
+	}
+
+	overrides, err := s.db.GetClassNotificationTemplates(ctx, classIds)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(classTemplates, len(overrides))
+	for _, override := range overrides {
+		t, err := classTemplateParams(override).parse()
+		if err != nil {
+			s.l.Warn(
+				fmt.Sprintf("%s - could not parse class notification templates, using built-in templates", Namespace),
+				zap.Int64("class_id", override.ClassID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		templates[override.ClassID] = t
+	}
+
+	return templates, nil
+}
+
+func classTemplateParams(override model.ClassNotificationTemplate) ClassTemplateParams {
+	return ClassTemplateParams{
+		UserTextTemplate:        override.UserTextTemplate,
+		UserHtmlTemplate:        override.UserHTMLTemplate,
+		RuleCreatorTextTemplate: override.RuleCreatorTextTemplate,
+		RuleCreatorHtmlTemplate: override.RuleCreatorHTMLTemplate,
+	}
+}
+
+// renderTemplates executes the text and html templates of a mail, using the built-in templates for those that are not
+// given. If the class templates fail to execute, the built-in templates are used instead.
+func (s *Service) renderTemplates(
+	text, builtInText *texttemplate.Template,
+	html, builtInHtml *htmltemplate.Template,
+	args any,
+) (string, string, error) {
+	plainText, htmlText, err := executeTemplates(text, builtInText, html, builtInHtml, args)
+	if err == nil || (text == nil && html == nil) {
+		return plainText, htmlText, err
+	}
+
+	s.l.Warn(fmt.Sprintf("%s - could not execute class notification templates, using built-in templates", Namespace), zap.Error(err))
+	return executeTemplates(nil, builtInText, nil, builtInHtml, args)
+}
+
+// TemplatePreview contains the mails rendered from the notification templates of a class.
+type TemplatePreview struct {
+	UserSubject        string `json:"user_subject"`
+	UserText           string `json:"user_text"`
+	UserHtml           string `json:"user_html"`
+	RuleCreatorSubject string `json:"rule_creator_subject"`
+	RuleCreatorText    string `json:"rule_creator_text"`
+	RuleCreatorHtml    string `json:"rule_creator_html"`
+}
+
+// PreviewClassTemplates renders the given notification templates of a class with a sample student who failed the
+// given rules of the class. A sample rule is used if no rules are given. Templates that are not given are rendered from
+// the built-in warning templates. Unlike an intervention run, a template that fails to execute is returned as an error.
+func PreviewClassTemplates(class database.CoordinatingClass, classRules []model.ClassAttendanceRule, params ClassTemplateParams) (TemplatePreview, error) {
+	t, err := params.parse()
+	if err != nil {
+		return TemplatePreview{}, err
+	}
+
+	return t.preview(class, classRules)
+}
+
+// preview renders the templates with a sample student who failed the given rules of a class. A sample rule is used if
+// no rules are given.
+func (t *classTemplate) preview(class database.CoordinatingClass, classRules []model.ClassAttendanceRule) (TemplatePreview, error) {
+	if len(classRules) == 0 {
+		classRules = []model.ClassAttendanceRule{{
+			ClassID:     class.ID,
+			Title:       sampleRuleTitle,
+			Description: sampleRuleDescription,
+			Severity:    model.RuleSeverity_Warning,
+		}}
+	}
+
+	user := userKey{sampleUserId, sampleUserName, sampleUserEmail}
+	ruleInfos := make([]database.RuleInfo, 0, len(classRules))
+	ruleAndUsers := make([]ruleAndFailedUsers, 0, len(classRules))
+	for _, rule := range classRules {
+		ruleInfo := database.RuleInfo{
+			ClassAttendanceRule: rule,
+			ClassCode:           class.Code,
+			ClassYear:           class.Year,
+			ClassSemester:       class.Semester,
+		}
+
+		ruleInfos = append(ruleInfos, ruleInfo)
+		ruleAndUsers = append(ruleAndUsers, ruleAndFailedUsers{ruleInfo, []userKey{user}})
+	}
+
+	email := userEmails[model.RuleSeverity_Warning]
+	preview := TemplatePreview{
+		UserSubject:        email.subject,
+		RuleCreatorSubject: ruleCreatorEmailSubject,
+	}
+
+	var err error
+	preview.UserText, preview.UserHtml, err = executeTemplates(
+		t.userText, email.text, t.userHtml, email.html, userEmailArgs{user, ruleInfos},
+	)
+	if err != nil {
+		return TemplatePreview{}, fmt.Errorf("could not render user templates: %w", err)
+	}
+
+	preview.RuleCreatorText, preview.RuleCreatorHtml, err = executeTemplates(
+		t.ruleCreatorText, ruleCreatorTextEmail, t.ruleCreatorHtml, ruleCreatorHtmlEmail,
+		ruleCreatorEmailArgs{userKey{sampleCreatorId, sampleCreatorName, sampleCreatorEmail}, ruleAndUsers},
+	)
+	if err != nil {
+		return TemplatePreview{}, fmt.Errorf("could not render rule creator templates: %w", err)
+	}
+
+	return preview, nil
+}
+
+// executeTemplates executes the text and html templates of a mail, using the built-in templates for those that are
+// not given.
+func executeTemplates(
+	text, builtInText *texttemplate.Template,
+	html, builtInHtml *htmltemplate.Template,
+	args any,
+) (string, string, error) {
+	var textBuilder, htmlBuilder strings.Builder
+
+	if text == nil {
+		text = builtInText
+	}
+
+	if html == nil {
+		html = builtInHtml
+	}
+
+	if err := text.Execute(&textBuilder, args); err != nil {
+		return "", "", err
+	}
+
+	if err := html.Execute(&htmlBuilder, args); err != nil {
+		return "", "", err
+	}
+
+	return textBuilder.String(), htmlBuilder.String(), nil
+}
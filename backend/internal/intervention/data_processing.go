@@ -1,6 +1,8 @@
 package intervention
 
 import (
+	"slices"
+
 	"github.com/darylhjd/oams/backend/internal/database"
 	"github.com/darylhjd/oams/backend/internal/rules"
 )
@@ -8,7 +10,7 @@ import (
 type factGrouping map[int64]map[userKey][]rules.Fact
 
 // groupFacts first by class, and then by user.
-func (s *Service) groupFacts(facts []rules.Fact) factGrouping {
+func groupFacts(facts []rules.Fact) factGrouping {
 	grouping := factGrouping{}
 
 	for _, f := range facts {
@@ -27,6 +29,24 @@ func (s *Service) groupFacts(facts []rules.Fact) factGrouping {
 	return grouping
 }
 
+// groupFactsByUser groups facts by user across all classes. The facts of each user are ordered by session start time.
+func groupFactsByUser(facts []rules.Fact) map[userKey][]rules.Fact {
+	grouping := map[userKey][]rules.Fact{}
+
+	for _, f := range facts {
+		key := userKey{f.UserID, f.UserName, f.UserEmail}
+		grouping[key] = append(grouping[key], f)
+	}
+
+	for _, userFacts := range grouping {
+		slices.SortStableFunc(userFacts, func(a, b rules.Fact) int {
+			return a.StartTime.Compare(b.StartTime)
+		})
+	}
+
+	return grouping
+}
+
 type ruleGrouping map[int64][]database.RuleInfo
 
 // groupRules by class.
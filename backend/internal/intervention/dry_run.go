@@ -0,0 +1,47 @@
+package intervention
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/darylhjd/oams/backend/internal/rules"
+)
+
+// FlaggedUser is a user who triggers a rule.
+type FlaggedUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// DryRun evaluates a rule against the facts of a class in the same way as an intervention run, without persisting
+// anything or sending mail. The users who trigger the rule are returned in order of their ID, together with the
+// number of users that the rule was evaluated against.
+func DryRun(rule string, env rules.E, facts []rules.Fact) ([]FlaggedUser, int, error) {
+	prg, err := rules.Compile(rule, env)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to compile rule: %w", err)
+	}
+
+	flagged := make([]FlaggedUser, 0)
+	evaluated := 0
+	for _, group := range groupFacts(facts) {
+		failedUsers, err := evaluateRule(prg, env, group)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to run rule: %w", err)
+		}
+
+		for _, user := range failedUsers {
+			flagged = append(flagged, FlaggedUser{user.ID, user.Name, user.Email})
+		}
+
+		evaluated += len(group)
+	}
+
+	slices.SortFunc(flagged, func(a, b FlaggedUser) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return flagged, evaluated, nil
+}
@@ -0,0 +1,97 @@
+package intervention
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	"go.uber.org/zap"
+)
+
+// globalRuleResult is the result of evaluating a global rule against the facts of each user across all classes. If
+// the rule could not be evaluated, Error contains the reason.
+type globalRuleResult struct {
+	Rule        database.GlobalRuleInfo
+	FailedUsers []userKey
+	Error       string
+}
+
+// performGlobalChecks evaluates global rules against the facts of each user across all classes. A rule that fails to
+// compile or run does not stop the evaluation of other rules.
+func (s *Service) performGlobalChecks(facts []rules.Fact, globalRules []database.GlobalRuleInfo) []globalRuleResult {
+	group := groupFactsByUser(facts)
+	results := make([]globalRuleResult, 0, len(globalRules))
+
+	for _, rule := range globalRules {
+		s.l.Info(fmt.Sprintf("%s - performing global rule checks", Namespace), zap.Int64("global_rule_id", rule.ID))
+
+		result := globalRuleResult{Rule: rule}
+
+		prg, err := rules.Compile(rule.Rule, rule.Environment.Env)
+		if err == nil {
+			result.FailedUsers, err = evaluateRule(prg, rule.Environment.Env, group)
+		}
+
+		if err != nil {
+			s.l.Warn(
+				fmt.Sprintf("%s - could not evaluate global rule", Namespace),
+				zap.Int64("global_rule_id", rule.ID),
+				zap.Error(err),
+			)
+
+			result.Error = err.Error()
+		}
+
+		slices.SortFunc(result.FailedUsers, func(a, b userKey) int {
+			return strings.Compare(a.ID, b.ID)
+		})
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// usersAndTerms returns the distinct users and terms of the given facts.
+func usersAndTerms(facts []rules.Fact) ([]string, []database.Term) {
+	var (
+		userIds []string
+		terms   []database.Term
+	)
+
+	seenUsers, seenTerms := map[string]struct{}{}, map[database.Term]struct{}{}
+	for _, fact := range facts {
+		if _, ok := seenUsers[fact.UserID]; !ok {
+			seenUsers[fact.UserID] = struct{}{}
+			userIds = append(userIds, fact.UserID)
+		}
+
+		term := database.Term{Year: fact.ClassYear, Semester: fact.ClassSemester}
+		if _, ok := seenTerms[term]; !ok {
+			seenTerms[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+
+	return userIds, terms
+}
+
+// globalRuleFailures returns the global rules that could not be evaluated, to be recorded as failures of a run.
+func globalRuleFailures(results []globalRuleResult) []database.CreateInterventionRunRuleFailureParams {
+	var failures []database.CreateInterventionRunRuleFailureParams
+	for _, result := range results {
+		if result.Error == "" {
+			continue
+		}
+
+		failures = append(failures, database.CreateInterventionRunRuleFailureParams{
+			GlobalRuleID: &result.Rule.ID,
+			RuleTitle:    result.Rule.Title,
+			Reason:       result.Error,
+		})
+	}
+
+	return failures
+}
@@ -4,62 +4,168 @@ import (
 	"strings"
 
 	"github.com/darylhjd/azmail"
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 )
 
-func (s *Service) generateNotificationMails(users userFailedRules, ruleCreators ruleCreatorRuleFailedUsers) ([]*azmail.Mail, error) {
-	mails := make([]*azmail.Mail, 0, len(users)+len(ruleCreators))
+// userNotification is a mail to a user together with the rules that the user is notified of in it.
+type userNotification struct {
+	Mail    *azmail.Mail
+	UserID  string
+	RuleIDs []int64
+}
 
-	// For each pair of user and the rule the user failed.
-	for user, rules := range users {
-		var (
-			textBuilder strings.Builder
-			htmlBuilder strings.Builder
-			args        = userEmailArgs{user, rules}
-		)
+// generateNotificationMails generates the mails to users and rule creators. Rules of classes with their own
+// notification templates are sent in separate mails using those templates. The rules that each user is notified of
+// are also returned for each mail to a user.
+func (s *Service) generateNotificationMails(users userFailedRules, ruleCreators ruleCreatorRuleFailedUsers, templates classTemplates) ([]*azmail.Mail, []userNotification, error) {
+	mails := make([]*azmail.Mail, 0, len(users)+len(ruleCreators))
+	notifications := make([]userNotification, 0, len(users))
 
-		if err := userTextEmail.Execute(&textBuilder, args); err != nil {
-			return nil, err
+	// For each mail to a user and the rules the user failed. The severity of the rules decides the mail template.
+	for mailKey, rules := range users {
+		email, ok := userEmails[mailKey.Severity]
+		if !ok {
+			email = userEmails[model.RuleSeverity_Warning]
 		}
 
-		if err := userHtmlEmail.Execute(&htmlBuilder, args); err != nil {
-			return nil, err
+		classIds, classRules := splitByTemplateClass(rules, func(rule database.RuleInfo) int64 {
+			return templates.userTemplateClass(rule.ClassID)
+		})
+
+		for _, classId := range classIds {
+			text, html := email.text, email.html
+			if ct, ok := templates[classId]; ok {
+				text, html = ct.userText, ct.userHtml
+			}
+
+			plainText, htmlText, err := s.renderTemplates(
+				text, email.text, html, email.html, userEmailArgs{mailKey.User, classRules[classId]},
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			mail := azmail.NewMail()
+			mail.Recipients = azmail.MailRecipients{
+				To: []azmail.MailAddress{{Address: mailKey.User.Email, DisplayName: mailKey.User.Name}},
+				Cc: escalationContacts(mailKey),
+			}
+			mail.Content = azmail.MailContent{
+				Subject:   email.subject,
+				PlainText: plainText,
+				Html:      htmlText,
+			}
+
+			ruleIds := make([]int64, 0, len(classRules[classId]))
+			for _, rule := range classRules[classId] {
+				ruleIds = append(ruleIds, rule.ID)
+			}
+
+			mails = append(mails, mail)
+			notifications = append(notifications, userNotification{mail, mailKey.User.ID, ruleIds})
 		}
+	}
 
-		mail := azmail.NewMail()
-		mail.Recipients = azmail.MailRecipients{
-			To: []azmail.MailAddress{{user.Email, user.Name}},
+	// For each pair of rule creator and their rule with failed users.
+	for creator, ruleWithFailedUsers := range ruleCreators {
+		classIds, classRules := splitByTemplateClass(ruleWithFailedUsers, func(rule ruleAndFailedUsers) int64 {
+			return templates.ruleCreatorTemplateClass(rule.Rule.ClassID)
+		})
+
+		for _, classId := range classIds {
+			text, html := ruleCreatorTextEmail, ruleCreatorHtmlEmail
+			if ct, ok := templates[classId]; ok {
+				text, html = ct.ruleCreatorText, ct.ruleCreatorHtml
+			}
+
+			plainText, htmlText, err := s.renderTemplates(
+				text, ruleCreatorTextEmail, html, ruleCreatorHtmlEmail,
+				ruleCreatorEmailArgs{creator, classRules[classId]},
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			mail := azmail.NewMail()
+			mail.Recipients = azmail.MailRecipients{
+				To: []azmail.MailAddress{{Address: creator.Email, DisplayName: creator.Name}},
+			}
+			mail.Content = azmail.MailContent{
+				Subject:   ruleCreatorEmailSubject,
+				PlainText: plainText,
+				Html:      htmlText,
+			}
+
+			mails = append(mails, mail)
 		}
-		mail.Content = azmail.MailContent{
-			Subject:   userEmailSubject,
-			PlainText: textBuilder.String(),
-			Html:      htmlBuilder.String(),
+	}
+
+	return mails, notifications, nil
+}
+
+// splitByTemplateClass splits the rules of a mail by the class whose templates are used for them. The classes are
+// returned in the order that they first appear, and zero is used for rules that use the built-in templates.
+func splitByTemplateClass[T any](rules []T, templateClass func(T) int64) ([]int64, map[int64][]T) {
+	var classIds []int64
+	classRules := map[int64][]T{}
+	for _, rule := range rules {
+		classId := templateClass(rule)
+		if _, ok := classRules[classId]; !ok {
+			classIds = append(classIds, classId)
 		}
 
-		mails = append(mails, mail)
+		classRules[classId] = append(classRules[classId], rule)
 	}
 
-	// For each pair of rule creator and their rule with failed users.
-	for creator, ruleWithFailedUsers := range ruleCreators {
+	return classIds, classRules
+}
+
+// escalationContacts returns the contacts that are copied in on a mail to a user because of escalation.
+func escalationContacts(mailKey userMailKey) []azmail.MailAddress {
+	var contacts []azmail.MailAddress
+
+	if mailKey.Coordinator != (userKey{}) {
+		contacts = append(contacts, azmail.MailAddress{Address: mailKey.Coordinator.Email, DisplayName: mailKey.Coordinator.Name})
+	}
+
+	if mailKey.AdvisorEmail != "" {
+		contacts = append(contacts, azmail.MailAddress{Address: mailKey.AdvisorEmail})
+	}
+
+	return contacts
+}
+
+// generateQuarantineMails notifies the creator of each quarantined rule.
+func (s *Service) generateQuarantineMails(quarantined []quarantinedRule) ([]*azmail.Mail, error) {
+	ruleCreators := map[userKey][]quarantinedRule{}
+	for _, q := range quarantined {
+		creatorKey := userKey{q.Rule.CreatorID, q.Rule.CreatorName, q.Rule.CreatorEmail}
+		ruleCreators[creatorKey] = append(ruleCreators[creatorKey], q)
+	}
+
+	mails := make([]*azmail.Mail, 0, len(ruleCreators))
+	for creator, rules := range ruleCreators {
 		var (
 			textBuilder strings.Builder
 			htmlBuilder strings.Builder
-			args        = ruleCreatorEmailArgs{creator, ruleWithFailedUsers}
+			args        = ruleQuarantineEmailArgs{creator, rules}
 		)
 
-		if err := ruleCreatorTextEmail.Execute(&textBuilder, args); err != nil {
+		if err := ruleQuarantineTextEmail.Execute(&textBuilder, args); err != nil {
 			return nil, err
 		}
 
-		if err := ruleCreatorHtmlEmail.Execute(&htmlBuilder, args); err != nil {
+		if err := ruleQuarantineHtmlEmail.Execute(&htmlBuilder, args); err != nil {
 			return nil, err
 		}
 
 		mail := azmail.NewMail()
 		mail.Recipients = azmail.MailRecipients{
-			To: []azmail.MailAddress{{creator.Email, creator.Name}},
+			To: []azmail.MailAddress{{Address: creator.Email, DisplayName: creator.Name}},
 		}
 		mail.Content = azmail.MailContent{
-			Subject:   ruleCreatorEmailSubject,
+			Subject:   ruleQuarantineSubject,
 			PlainText: textBuilder.String(),
 			Html:      htmlBuilder.String(),
 		}
@@ -69,3 +175,42 @@ func (s *Service) generateNotificationMails(users userFailedRules, ruleCreators
 
 	return mails, nil
 }
+
+// generateGlobalRuleMail reports the results of global rules to the student affairs mailbox.
+func (s *Service) generateGlobalRuleMail(results []globalRuleResult) (*azmail.Mail, error) {
+	var (
+		textBuilder strings.Builder
+		htmlBuilder strings.Builder
+		args        = globalRuleEmailArgs{results}
+	)
+
+	if err := globalRuleTextEmail.Execute(&textBuilder, args); err != nil {
+		return nil, err
+	}
+
+	if err := globalRuleHtmlEmail.Execute(&htmlBuilder, args); err != nil {
+		return nil, err
+	}
+
+	mail := azmail.NewMail()
+	mail.Recipients = azmail.MailRecipients{
+		To: []azmail.MailAddress{{Address: s.studentAffairsEmail}},
+	}
+	mail.Content = azmail.MailContent{
+		Subject:   globalRuleEmailSubject,
+		PlainText: textBuilder.String(),
+		Html:      htmlBuilder.String(),
+	}
+
+	return mail, nil
+}
+
+// joinAddresses returns the addresses of a list of recipients as a single comma-separated string.
+func joinAddresses(addresses []azmail.MailAddress) string {
+	res := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		res = append(res, address.Address)
+	}
+
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,128 @@
+package intervention
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+)
+
+// userMailKey identifies a notification mail to a user. Rules are sent in separate mails by severity, and by the
+// contacts that are copied in on the mail because of the escalation policies of the rules.
+type userMailKey struct {
+	User         userKey
+	Severity     model.RuleSeverity
+	Coordinator  userKey
+	AdvisorEmail string
+}
+
+// userFailedRules contains lists of database.RuleInfo for each notification mail to a user.
+// The list consists of database.RuleInfo that each user broke.
+type userFailedRules map[userMailKey][]database.RuleInfo
+
+// ruleCreatorRuleFailedUsers contains lists of ruleAndFailedUsers for each rule creator.
+// The list consists of ruleAndFailedUsers, of which the rule belongs to the creator.
+type ruleCreatorRuleFailedUsers map[userKey][]ruleAndFailedUsers
+
+type ruleUserKey struct {
+	RuleID int64
+	UserID string
+}
+
+// selectNotifications returns, for each evaluation, the number of the notification that the user should receive for
+// it, counted from 1 since the user last did not trigger the rule. Zero means that the user should not be notified. A
+// user is notified when the user starts triggering a rule, and then again after each cooldown of the rule while the
+// user keeps triggering it.
+func selectNotifications(evaluations []ruleEvaluation, states []database.RuleEvaluationState, now time.Time) []int {
+	latest := make(map[ruleUserKey]database.RuleEvaluationState, len(states))
+	for _, state := range states {
+		latest[ruleUserKey{state.RuleID, state.UserID}] = state
+	}
+
+	notify := make([]int, len(evaluations))
+	for idx, evaluation := range evaluations {
+		if !evaluation.Triggered {
+			continue
+		}
+
+		state, ok := latest[ruleUserKey{evaluation.Rule.ID, evaluation.User.ID}]
+		if shouldNotify(state, ok, evaluation.Rule.NotificationCooldownDays, now) {
+			notify[idx] = state.NotifiedCount + 1
+		}
+	}
+
+	return notify
+}
+
+// shouldNotify checks if a user who triggers a rule should be notified, given the latest evaluation of the rule for
+// the user. The cooldown is counted in calendar days from the day the user was last notified.
+func shouldNotify(state database.RuleEvaluationState, ok bool, cooldownDays int32, now time.Time) bool {
+	if !ok || !state.Triggered || state.LastNotifiedAt == nil {
+		return true
+	}
+
+	lastNotifiedAt := state.LastNotifiedAt.In(now.Location())
+	nextNotificationAt := time.Date(
+		lastNotifiedAt.Year(), lastNotifiedAt.Month(), lastNotifiedAt.Day()+int(cooldownDays),
+		0, 0, 0, 0, now.Location(),
+	)
+
+	return !now.Before(nextNotificationAt)
+}
+
+// groupNotifications groups the evaluations that users should be notified of by user mail and by rule creator. The
+// escalation policy of each rule decides who is copied in on the user mail. Every evaluated rule is reported to its
+// creator, even if no user is notified of it, with the most severe rules first.
+func groupNotifications(evaluations []ruleEvaluation, notify []int) (userFailedRules, ruleCreatorRuleFailedUsers) {
+	users := userFailedRules{}
+	ruleCreators := ruleCreatorRuleFailedUsers{}
+	ruleIndex := map[int64]int{}
+
+	for idx, evaluation := range evaluations {
+		rule := evaluation.Rule
+		creatorKey := userKey{rule.CreatorID, rule.CreatorName, rule.CreatorEmail}
+
+		ruleIdx, ok := ruleIndex[rule.ID]
+		if !ok {
+			ruleCreators[creatorKey] = append(ruleCreators[creatorKey], ruleAndFailedUsers{Rule: rule})
+			ruleIdx = len(ruleCreators[creatorKey]) - 1
+			ruleIndex[rule.ID] = ruleIdx
+		}
+
+		if notify[idx] == 0 {
+			continue
+		}
+
+		mailKey := userMailKey{User: evaluation.User, Severity: rule.Severity}
+		step := rule.EscalationPolicy.StepFor(notify[idx])
+		if step.CCCoordinator {
+			mailKey.Coordinator = creatorKey
+		}
+		mailKey.AdvisorEmail = step.AdvisorEmail
+
+		users[mailKey] = append(users[mailKey], rule)
+		ruleCreators[creatorKey][ruleIdx].FailedUsers = append(ruleCreators[creatorKey][ruleIdx].FailedUsers, evaluation.User)
+	}
+
+	for _, rules := range ruleCreators {
+		slices.SortStableFunc(rules, func(a, b ruleAndFailedUsers) int {
+			return cmp.Compare(severityRank(b.Rule.Severity), severityRank(a.Rule.Severity))
+		})
+	}
+
+	return users, ruleCreators
+}
+
+// severityRank orders rule severities from the least to the most severe.
+func severityRank(severity model.RuleSeverity) int {
+	switch severity {
+	case model.RuleSeverity_Info:
+		return 0
+	case model.RuleSeverity_Critical:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,161 @@
+package intervention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_selectNotifications(t *testing.T) {
+	now := time.Date(2024, time.March, 12, 18, 0, 0, 0, datetime.Location)
+	rule := database.RuleInfo{
+		ClassAttendanceRule: model.ClassAttendanceRule{
+			ID:                       1,
+			NotificationCooldownDays: 7,
+		},
+	}
+	user := userKey{"USER1", "User 1", "user1@example.com"}
+
+	tts := []struct {
+		name          string
+		withTriggered bool
+		withStates    []database.RuleEvaluationState
+		wantNotify    []int
+	}{
+		{
+			"user does not trigger rule",
+			false,
+			nil,
+			[]int{0},
+		},
+		{
+			"user triggers rule for the first time",
+			true,
+			nil,
+			[]int{1},
+		},
+		{
+			"user starts triggering rule again",
+			true,
+			[]database.RuleEvaluationState{
+				{RuleID: 1, UserID: "USER1", Triggered: false, LastNotifiedAt: to.Ptr(now.AddDate(0, 0, -1))},
+			},
+			[]int{1},
+		},
+		{
+			"user keeps triggering rule within cooldown",
+			true,
+			[]database.RuleEvaluationState{
+				{RuleID: 1, UserID: "USER1", Triggered: true, LastNotifiedAt: to.Ptr(now.AddDate(0, 0, -6)), NotifiedCount: 1},
+			},
+			[]int{0},
+		},
+		{
+			"user keeps triggering rule after cooldown",
+			true,
+			[]database.RuleEvaluationState{
+				{RuleID: 1, UserID: "USER1", Triggered: true, LastNotifiedAt: to.Ptr(now.AddDate(0, 0, -7)), NotifiedCount: 2},
+			},
+			[]int{3},
+		},
+		{
+			"user keeps triggering rule but was never notified",
+			true,
+			[]database.RuleEvaluationState{
+				{RuleID: 1, UserID: "USER1", Triggered: true},
+			},
+			[]int{1},
+		},
+		{
+			"state of another user",
+			true,
+			[]database.RuleEvaluationState{
+				{RuleID: 1, UserID: "USER2", Triggered: true, LastNotifiedAt: to.Ptr(now), NotifiedCount: 1},
+			},
+			[]int{1},
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			evaluations := []ruleEvaluation{{rule, user, tt.withTriggered}}
+			a.Equal(tt.wantNotify, selectNotifications(evaluations, tt.withStates, now))
+		})
+	}
+}
+
+func Test_shouldNotify(t *testing.T) {
+	now := time.Date(2024, time.March, 12, 8, 0, 0, 0, datetime.Location)
+
+	tts := []struct {
+		name             string
+		withState        database.RuleEvaluationState
+		withOk           bool
+		withCooldownDays int32
+		wantNotify       bool
+	}{
+		{
+			"no previous evaluation",
+			database.RuleEvaluationState{},
+			false,
+			7,
+			true,
+		},
+		{
+			"previously not triggered",
+			database.RuleEvaluationState{Triggered: false, LastNotifiedAt: to.Ptr(now)},
+			true,
+			7,
+			true,
+		},
+		{
+			"previously triggered but never notified",
+			database.RuleEvaluationState{Triggered: true},
+			true,
+			7,
+			true,
+		},
+		{
+			"notified on the last day of cooldown",
+			database.RuleEvaluationState{
+				Triggered:      true,
+				LastNotifiedAt: to.Ptr(time.Date(2024, time.March, 6, 23, 0, 0, 0, datetime.Location)),
+			},
+			true,
+			7,
+			false,
+		},
+		{
+			"cooldown counted in calendar days",
+			database.RuleEvaluationState{
+				Triggered:      true,
+				LastNotifiedAt: to.Ptr(time.Date(2024, time.March, 5, 23, 0, 0, 0, datetime.Location)),
+			},
+			true,
+			7,
+			true,
+		},
+		{
+			"zero cooldown",
+			database.RuleEvaluationState{Triggered: true, LastNotifiedAt: to.Ptr(now)},
+			true,
+			0,
+			true,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			a.Equal(tt.wantNotify, shouldNotify(tt.withState, tt.withOk, tt.withCooldownDays, now))
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package intervention
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/darylhjd/azmail"
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/env"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+	"go.uber.org/zap"
+)
+
+// MailPreview is a mail that an intervention run would send.
+type MailPreview struct {
+	To        []MailPreviewAddress `json:"to"`
+	Cc        []MailPreviewAddress `json:"cc"`
+	Subject   string               `json:"subject"`
+	PlainText string               `json:"plain_text"`
+	Html      string               `json:"html"`
+}
+
+type MailPreviewAddress struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+}
+
+// PreviewParams chooses what an intervention preview checks. At is the time that the run is previewed at. If ClassID
+// is set, only the rules of that class are checked and global rules are skipped.
+type PreviewParams struct {
+	At      time.Time
+	ClassID *int64
+}
+
+// PreviewTime returns the time that a preview of the given date is made at, which is the last instant of that day, or
+// now if the date is today. The date is parsed in datetime.Location with the time.DateOnly layout, the same day that an
+// intervention rerun uses. An empty date previews now.
+func PreviewTime(date string, now time.Time) (time.Time, error) {
+	if date == "" {
+		return now, nil
+	}
+
+	day, err := time.ParseInLocation(time.DateOnly, date, datetime.Location)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// The last instant of the day, so that sessions ending at midnight are still checked.
+	at := day.AddDate(0, 0, 1).Add(-time.Microsecond)
+	if day.After(now) {
+		return time.Time{}, fmt.Errorf("cannot preview future date %s", date)
+	} else if at.After(now) {
+		return now, nil
+	}
+
+	return at, nil
+}
+
+// Preview returns the mails that an intervention run would send using an existing logger and database connection. No
+// mailer is needed. See Service.Preview.
+func Preview(ctx context.Context, l *zap.Logger, db *database.DB, arg PreviewParams) ([]MailPreview, error) {
+	s := &Service{
+		l:                   l,
+		db:                  db,
+		studentAffairsEmail: env.GetStudentAffairsEmail(),
+	}
+
+	return s.Preview(ctx, arg)
+}
+
+// Preview returns the mails that an intervention run at the given time would send, without recording evaluations,
+// quarantining rules or sending anything. If a class is given, the rules of that class are checked against all its
+// sessions up to that time, whether or not it had sessions on that day, and global rules are skipped. Mails are ordered
+// by their first recipient and subject.
+func (s *Service) Preview(ctx context.Context, arg PreviewParams) ([]MailPreview, error) {
+	s.l.Info(
+		fmt.Sprintf("%s - intervention preview invoked", Namespace),
+		zap.Time("time", time.Now()),
+		zap.Time("run_at", arg.At),
+	)
+
+	var (
+		facts     []rules.Fact
+		ruleInfos []database.RuleInfo
+		err       error
+	)
+
+	if arg.ClassID != nil {
+		facts, ruleInfos, err = s.db.ClassIntervention(ctx, *arg.ClassID, arg.At)
+	} else {
+		facts, ruleInfos, err = s.db.Intervention(ctx, arg.At)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations, failed := s.performChecks(groupFacts(facts), s.groupRules(ruleInfos))
+	quarantined, err := s.selectQuarantinedRules(ctx, failed)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := s.db.GetRuleEvaluationStates(ctx, evaluatedRuleIds(evaluations))
+	if err != nil {
+		return nil, err
+	}
+
+	users, ruleCreators := groupNotifications(evaluations, selectNotifications(evaluations, states, arg.At))
+	templates, err := s.getClassTemplates(ctx, ruleInfos)
+	if err != nil {
+		return nil, err
+	}
+
+	mails, _, err := s.generateNotificationMails(users, ruleCreators, templates)
+	if err != nil {
+		return nil, err
+	}
+
+	quarantineMails, err := s.generateQuarantineMails(quarantined)
+	if err != nil {
+		return nil, err
+	}
+
+	mails = append(mails, quarantineMails...)
+
+	if arg.ClassID == nil {
+		globalMail, _, err := s.checkGlobalRules(ctx, arg.At, facts)
+		if err != nil {
+			return nil, err
+		}
+
+		if globalMail != nil {
+			mails = append(mails, globalMail)
+		}
+	}
+
+	return previewMails(mails), nil
+}
+
+func previewMails(mails []*azmail.Mail) []MailPreview {
+	addresses := func(mailAddresses []azmail.MailAddress) []MailPreviewAddress {
+		res := make([]MailPreviewAddress, 0, len(mailAddresses))
+		for _, address := range mailAddresses {
+			res = append(res, MailPreviewAddress{address.Address, address.DisplayName})
+		}
+
+		return res
+	}
+
+	previews := make([]MailPreview, 0, len(mails))
+	for _, mail := range mails {
+		previews = append(previews, MailPreview{
+			To:        addresses(mail.Recipients.To),
+			Cc:        addresses(mail.Recipients.Cc),
+			Subject:   mail.Content.Subject,
+			PlainText: mail.Content.PlainText,
+			Html:      mail.Content.Html,
+		})
+	}
+
+	slices.SortFunc(previews, func(a, b MailPreview) int {
+		var aTo, bTo string
+		if len(a.To) > 0 {
+			aTo = a.To[0].Address
+		}
+
+		if len(b.To) > 0 {
+			bTo = b.To[0].Address
+		}
+
+		return cmp.Or(cmp.Compare(aTo, bTo), cmp.Compare(a.Subject, b.Subject))
+	})
+
+	return previews
+}
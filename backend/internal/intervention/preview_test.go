@@ -0,0 +1,66 @@
+package intervention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewTime(t *testing.T) {
+	now := time.Date(2024, time.March, 12, 15, 30, 0, 0, datetime.Location)
+
+	tts := []struct {
+		name     string
+		withDate string
+		wantTime time.Time
+		wantErr  bool
+	}{
+		{
+			"no date",
+			"",
+			now,
+			false,
+		},
+		{
+			"past date",
+			"2024-03-10",
+			time.Date(2024, time.March, 10, 23, 59, 59, 999999000, datetime.Location),
+			false,
+		},
+		{
+			"today",
+			"2024-03-12",
+			now,
+			false,
+		},
+		{
+			"future date",
+			"2024-03-13",
+			time.Time{},
+			true,
+		},
+		{
+			"invalid date",
+			"12/03/2024",
+			time.Time{},
+			true,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			at, err := PreviewTime(tt.withDate, now.UTC())
+			if tt.wantErr {
+				a.Error(err)
+				return
+			}
+
+			a.Nil(err)
+			a.True(tt.wantTime.Equal(at))
+		})
+	}
+}
@@ -2,25 +2,48 @@ package intervention
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/darylhjd/azmail"
 	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 	"github.com/darylhjd/oams/backend/internal/env"
 	"github.com/darylhjd/oams/backend/internal/logger"
+	"github.com/darylhjd/oams/backend/internal/mailer"
+	"github.com/darylhjd/oams/backend/internal/rules"
 	"go.uber.org/zap"
 )
 
 const (
 	Namespace = "intervention"
+
+	// maxConsecutiveRuleFailures is the number of runs in a row that a rule can exceed a limit in before it is
+	// quarantined.
+	maxConsecutiveRuleFailures = 3
+)
+
+var (
+	// RunLockKey is the advisory lock key held while the intervention service runs, so that runs from the scheduler,
+	// the Azure Functions handler and manual reruns do not overlap and send the same mails twice.
+	RunLockKey = database.AdvisoryLockKey(Namespace)
+
+	// ErrMailNotConfigured is returned when a run is requested but no mail transport is configured.
+	ErrMailNotConfigured = errors.New("mail transport is not configured")
+
+	// ErrRunInProgress is returned when a run is requested while another run is in progress.
+	ErrRunInProgress = errors.New("another intervention run is in progress")
 )
 
 type Service struct {
 	l  *zap.Logger
 	db *database.DB
 
-	mailer *azmail.Client
+	mailer mailer.Mailer
+
+	// studentAffairsEmail receives the results of global rules. Global rules are not evaluated if it is empty.
+	studentAffairsEmail string
 }
 
 // New creates the intervention service.
@@ -35,56 +58,401 @@ func New(ctx context.Context) (*Service, error) {
 		return nil, fmt.Errorf("%s - could not connect to database: %w", Namespace, err)
 	}
 
-	mailer, err := azmail.NewClient(
-		env.GetAzureEmailEndpoint(),
-		env.GetAzureEmailAccessKey(),
-		env.GetAzureEmailSenderAddress(),
-	)
+	m, err := mailer.New()
 	if err != nil {
-		return nil, fmt.Errorf("%s - could not create mailer client: %w", Namespace, err)
+		return nil, fmt.Errorf("%s - could not create mailer: %w", Namespace, err)
 	}
 
 	return &Service{
-		l, db, mailer,
+		l, db, m, env.GetStudentAffairsEmail(),
 	}, nil
 }
 
+// Run checks the rules of the classes that had sessions today and notifies users and rule creators. ErrRunInProgress
+// is returned without starting a run if another run holds RunLockKey.
 func (s *Service) Run(ctx context.Context) error {
-	s.l.Info(fmt.Sprintf("%s - intervention service invoked", Namespace), zap.Time("time", time.Now()))
+	release, err := lockRun(ctx, s.l, s.db)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	_, err = s.run(ctx, time.Now(), nil)
+	return err
+}
+
+// RunAt is similar to Run, but checks the classes that had sessions on the day of the given time, up to that time.
+// This allows the scheduler to catch up on runs that were missed. The caller must hold RunLockKey, as the scheduler
+// does while it runs the intervention job.
+func (s *Service) RunAt(ctx context.Context, at time.Time) error {
+	_, err := s.run(ctx, at, nil)
+	return err
+}
+
+// Rerun runs the intervention service for the day of the given time, up to that time, on behalf of a user. An existing
+// logger and database connection are used. The recorded run is returned, even if the run failed. ErrMailNotConfigured
+// is returned without starting a run if no mail transport is configured, and ErrRunInProgress if another run holds
+// RunLockKey.
+func Rerun(ctx context.Context, l *zap.Logger, db *database.DB, at time.Time, userId string) (model.InterventionRun, error) {
+	if !env.IsMailConfigured() {
+		return model.InterventionRun{}, ErrMailNotConfigured
+	}
+
+	m, err := mailer.New()
+	if err != nil {
+		return model.InterventionRun{}, fmt.Errorf("%s - could not create mailer: %w", Namespace, err)
+	}
+
+	release, err := lockRun(ctx, l, db)
+	if err != nil {
+		return model.InterventionRun{}, err
+	}
+	defer release()
+
+	s := &Service{
+		l, db, m, env.GetStudentAffairsEmail(),
+	}
+
+	return s.run(ctx, at, &userId)
+}
+
+// lockRun acquires RunLockKey, and returns a function that releases it. ErrRunInProgress is returned if another run
+// holds the lock.
+func lockRun(ctx context.Context, l *zap.Logger, db *database.DB) (func(), error) {
+	lock, ok, err := db.TryAdvisoryLock(ctx, RunLockKey)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, ErrRunInProgress
+	}
+
+	return func() {
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+			l.Warn(fmt.Sprintf("%s - could not release run lock", Namespace), zap.Error(err))
+		}
+	}, nil
+}
+
+// run performs an intervention run and records its history. The run is ended even if it fails.
+func (s *Service) run(ctx context.Context, at time.Time, triggeredBy *string) (model.InterventionRun, error) {
+	s.l.Info(
+		fmt.Sprintf("%s - intervention service invoked", Namespace),
+		zap.Time("time", time.Now()),
+		zap.Time("run_at", at),
+	)
+
+	run, err := s.db.CreateInterventionRun(ctx, database.CreateInterventionRunParams{
+		ScheduledFor: at,
+		TriggeredBy:  triggeredBy,
+	})
+	if err != nil {
+		return run, err
+	}
+
+	var stats database.EndInterventionRunParams
+	if err = s.execute(ctx, run.ID, at, &stats); err != nil {
+		reason := err.Error()
+		stats.Error = &reason
+	}
+
+	// Record the end of the run even if the run was cancelled.
+	ended, endErr := s.db.EndInterventionRun(context.WithoutCancel(ctx), run.ID, stats)
+	if endErr != nil {
+		return run, errors.Join(err, endErr)
+	}
+
+	s.l.Info(
+		fmt.Sprintf("%s - intervention service completed", Namespace),
+		zap.Time("time", time.Now()),
+		zap.Int64("run_id", run.ID),
+		zap.Error(err),
+	)
+
+	return ended, err
+}
 
-	facts, rules, err := s.db.Intervention(ctx)
+// execute checks the rules, records the evaluations and sends the mails of a run. The statistics of the run are
+// updated as it progresses.
+func (s *Service) execute(ctx context.Context, runId int64, at time.Time, stats *database.EndInterventionRunParams) error {
+	facts, rules, err := s.db.Intervention(ctx, at)
 	if err != nil {
 		return err
 	}
 
+	stats.NumFacts, stats.NumRules = int32(len(facts)), int32(len(rules))
 	s.l.Info(
 		fmt.Sprintf("%s - retrieved data from database", Namespace),
 		zap.Int("num_facts", len(facts)),
 		zap.Int("num_rules", len(rules)),
 	)
 
-	factGroups, ruleGroups := s.groupFacts(facts), s.groupRules(rules)
-	users, ruleCreators, err := s.performChecks(factGroups, ruleGroups)
+	factGroups, ruleGroups := groupFacts(facts), s.groupRules(rules)
+	evaluations, failed := s.performChecks(factGroups, ruleGroups)
+	quarantined, err := s.recordRuleFailures(ctx, runId, failed)
+	if err != nil {
+		return err
+	}
+
+	users, ruleCreators, err := s.recordEvaluations(ctx, runId, evaluations, at)
+	if err != nil {
+		return err
+	}
+
+	stats.NumEvaluations = int32(len(evaluations))
+
+	templates, err := s.getClassTemplates(ctx, rules)
+	if err != nil {
+		return err
+	}
+
+	mails, notifications, err := s.generateNotificationMails(users, ruleCreators, templates)
+	if err != nil {
+		return err
+	}
+
+	quarantineMails, err := s.generateQuarantineMails(quarantined)
 	if err != nil {
 		return err
 	}
 
-	mails, err := s.generateNotificationMails(users, ruleCreators)
+	mails = append(mails, quarantineMails...)
+
+	globalMail, globalResults, err := s.checkGlobalRules(ctx, at, facts)
 	if err != nil {
 		return err
 	}
 
+	if err = s.db.CreateInterventionRunRuleFailures(ctx, runId, globalRuleFailures(globalResults)); err != nil {
+		return err
+	}
+
+	if globalMail != nil {
+		mails = append(mails, globalMail)
+	}
+
 	s.l.Info(fmt.Sprintf("%s - sending notification mails", Namespace), zap.Int("num_mails", len(mails)))
 
-	if err = s.mailer.SendMails(mails...); err != nil {
-		s.l.Warn(
-			fmt.Sprintf("%s - some errors were encountered while sending notification mails", Namespace),
-			zap.Error(err),
-		)
+	deliveries := s.sendMails(mails)
+	stats.NumMails = int32(len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.Status == model.MailDeliveryStatus_Failed {
+			stats.NumFailedMails++
+		}
+	}
+
+	if err = s.db.CreateInterventionRunMails(ctx, runId, deliveries); err != nil {
+		return err
+	}
+
+	return s.db.MarkRuleEvaluationsNotified(ctx, runId, deliveredNotifications(mails, deliveries, notifications))
+}
+
+// deliveredNotifications returns the rules that users were notified of in the mails that were delivered. The
+// deliveries must be in the same order as the mails.
+func deliveredNotifications(
+	mails []*azmail.Mail,
+	deliveries []database.CreateInterventionRunMailParams,
+	notifications []userNotification,
+) []database.MarkRuleEvaluationsNotifiedParams {
+	delivered := make(map[*azmail.Mail]bool, len(mails))
+	for idx, mail := range mails {
+		delivered[mail] = deliveries[idx].Status == model.MailDeliveryStatus_Sent
+	}
+
+	var params []database.MarkRuleEvaluationsNotifiedParams
+	for _, notification := range notifications {
+		if delivered[notification.Mail] {
+			params = append(params, database.MarkRuleEvaluationsNotifiedParams{
+				UserID:  notification.UserID,
+				RuleIDs: notification.RuleIDs,
+			})
+		}
+	}
+
+	return params
+}
+
+// sendMails sends each mail separately so that the delivery status of each mail can be recorded.
+func (s *Service) sendMails(mails []*azmail.Mail) []database.CreateInterventionRunMailParams {
+	deliveries := make([]database.CreateInterventionRunMailParams, 0, len(mails))
+	for _, mail := range mails {
+		delivery := database.CreateInterventionRunMailParams{
+			ToAddresses: joinAddresses(mail.Recipients.To),
+			CcAddresses: joinAddresses(mail.Recipients.Cc),
+			Subject:     mail.Content.Subject,
+			Status:      model.MailDeliveryStatus_Sent,
+		}
+
+		if err := s.mailer.SendMails(mail); err != nil {
+			s.l.Warn(
+				fmt.Sprintf("%s - could not send notification mail", Namespace),
+				zap.String("to", delivery.ToAddresses),
+				zap.Error(err),
+			)
+
+			reason := err.Error()
+			delivery.Status, delivery.Error = model.MailDeliveryStatus_Failed, &reason
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries
+}
+
+// checkGlobalRules evaluates the active global rules and returns the mail reporting their results to the student
+// affairs mailbox, together with the results. No mail is returned if there are no active global rules or no student
+// affairs mailbox. The rules are evaluated against the facts of the users in the given facts across all their classes
+// of the same terms, up to the given time.
+func (s *Service) checkGlobalRules(ctx context.Context, at time.Time, facts []rules.Fact) (*azmail.Mail, []globalRuleResult, error) {
+	if s.studentAffairsEmail == "" {
+		s.l.Info(fmt.Sprintf("%s - no student affairs mailbox configured, skipping global rules", Namespace))
+		return nil, nil, nil
+	}
+
+	globalRules, err := s.db.GetActiveGlobalRules(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(globalRules) == 0 {
+		return nil, nil, nil
+	}
+
+	userIds, terms := usersAndTerms(facts)
+	termFacts, err := s.db.GetUserTermFacts(ctx, userIds, terms, at)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := s.performGlobalChecks(termFacts, globalRules)
+	mail, err := s.generateGlobalRuleMail(results)
+	return mail, results, err
+}
+
+// recordRuleFailures records the rules that failed to compile or run in this run as failures of the run, and
+// quarantines those selected by selectQuarantinedRules. The quarantined rules are returned.
+func (s *Service) recordRuleFailures(ctx context.Context, runId int64, failed []failedRule) ([]quarantinedRule, error) {
+	quarantined, err := s.selectQuarantinedRules(ctx, failed)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := make([]database.CreateInterventionRunRuleFailureParams, 0, len(failed))
+	for _, f := range failed {
+		failures = append(failures, database.CreateInterventionRunRuleFailureParams{
+			RuleID:    &f.Rule.ID,
+			RuleTitle: f.Rule.Title,
+			Reason:    f.Reason,
+		})
+	}
+
+	if err = s.db.CreateInterventionRunRuleFailures(ctx, runId, failures); err != nil {
+		return nil, err
+	}
+
+	for _, q := range quarantined {
+		if err = s.db.QuarantineRule(ctx, q.Rule.ID, q.Reason); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(quarantined) > 0 {
+		s.l.Info(fmt.Sprintf("%s - quarantined rules", Namespace), zap.Int("num_rules", len(quarantined)))
+	}
+
+	return quarantined, nil
+}
+
+// selectQuarantinedRules returns the failed rules that should be quarantined. A rule is quarantined at once if it
+// failed to compile or had an error while running. A rule that only exceeded a limit is quarantined if it also failed
+// in the previous runs, so that it is quarantined after maxConsecutiveRuleFailures runs in a row.
+func (s *Service) selectQuarantinedRules(ctx context.Context, failed []failedRule) ([]quarantinedRule, error) {
+	var transientIds []int64
+	for _, f := range failed {
+		if f.Transient {
+			transientIds = append(transientIds, f.Rule.ID)
+		}
+	}
+
+	counts, err := s.db.GetRuleFailureCounts(ctx, transientIds)
+	if err != nil {
+		return nil, err
+	}
+
+	previousFailures := make(map[int64]int, len(counts))
+	for _, count := range counts {
+		previousFailures[count.RuleID] = count.Count
+	}
+
+	var quarantined []quarantinedRule
+	for _, f := range failed {
+		if !f.Transient || previousFailures[f.Rule.ID]+1 >= maxConsecutiveRuleFailures {
+			quarantined = append(quarantined, quarantinedRule{f.Rule, f.Reason})
+		}
+	}
+
+	return quarantined, nil
+}
+
+// recordEvaluations records the results of the rule checks for this run. Only the evaluations that users should be
+// notified of are grouped and returned. Evaluations are recorded as not notified, and are only marked as notified once
+// the mail to the user is delivered.
+func (s *Service) recordEvaluations(ctx context.Context, runId int64, evaluations []ruleEvaluation, at time.Time) (userFailedRules, ruleCreatorRuleFailedUsers, error) {
+	txDb, tx, err := s.db.AsTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	states, err := txDb.GetRuleEvaluationStates(ctx, evaluatedRuleIds(evaluations))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notify := selectNotifications(evaluations, states, at)
+
+	params := make([]database.CreateRuleEvaluationParams, 0, len(evaluations))
+	for _, evaluation := range evaluations {
+		params = append(params, database.CreateRuleEvaluationParams{
+			RuleID:      evaluation.Rule.ID,
+			RuleVersion: evaluation.Rule.Version,
+			UserID:      evaluation.User.ID,
+			Triggered:   evaluation.Triggered,
+		})
+	}
+
+	if err = txDb.CreateRuleEvaluations(ctx, runId, params); err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	s.l.Info(
+		fmt.Sprintf("%s - recorded rule evaluations", Namespace),
+		zap.Int64("run_id", runId),
+		zap.Int("num_evaluations", len(evaluations)),
+	)
+
+	users, ruleCreators := groupNotifications(evaluations, notify)
+	return users, ruleCreators, nil
+}
+
+// evaluatedRuleIds returns the IDs of the rules that were evaluated, in order of their first evaluation.
+func evaluatedRuleIds(evaluations []ruleEvaluation) []int64 {
+	ruleIds := make([]int64, 0)
+	seen := map[int64]bool{}
+	for _, evaluation := range evaluations {
+		if !seen[evaluation.Rule.ID] {
+			seen[evaluation.Rule.ID] = true
+			ruleIds = append(ruleIds, evaluation.Rule.ID)
+		}
 	}
 
-	s.l.Info(fmt.Sprintf("%s - intervention service completed", Namespace), zap.Time("time", time.Now()))
-	return nil
+	return ruleIds
 }
 
 // Stop the intervention service gracefully.
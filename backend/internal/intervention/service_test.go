@@ -0,0 +1,130 @@
+package intervention
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/darylhjd/azmail"
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// failingMailer fails to send mails to the given address.
+type failingMailer struct {
+	address string
+}
+
+func (m failingMailer) SendMails(mails ...*azmail.Mail) error {
+	for _, mail := range mails {
+		for _, recipient := range mail.Recipients.To {
+			if recipient.Address == m.address {
+				return errors.New("mailbox unavailable")
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestService_sendMails(t *testing.T) {
+	a := assert.New(t)
+
+	s := &Service{l: zap.NewNop(), mailer: failingMailer{"user2@example.com"}}
+	mails := []*azmail.Mail{
+		{
+			Recipients: azmail.MailRecipients{
+				To: []azmail.MailAddress{{Address: "user1@example.com"}},
+				Cc: []azmail.MailAddress{{Address: "coordinator@example.com"}, {Address: "creator@example.com"}},
+			},
+			Content: azmail.MailContent{Subject: "OAMS: Attendance Check Failure"},
+		},
+		{
+			Recipients: azmail.MailRecipients{
+				To: []azmail.MailAddress{{Address: "user2@example.com"}},
+			},
+			Content: azmail.MailContent{Subject: "OAMS: Attendance Reminder"},
+		},
+	}
+
+	a.Equal([]database.CreateInterventionRunMailParams{
+		{
+			ToAddresses: "user1@example.com",
+			CcAddresses: "coordinator@example.com, creator@example.com",
+			Subject:     "OAMS: Attendance Check Failure",
+			Status:      model.MailDeliveryStatus_Sent,
+		},
+		{
+			ToAddresses: "user2@example.com",
+			Subject:     "OAMS: Attendance Reminder",
+			Status:      model.MailDeliveryStatus_Failed,
+			Error:       to.Ptr("mailbox unavailable"),
+		},
+	}, s.sendMails(mails))
+}
+
+func Test_deliveredNotifications(t *testing.T) {
+	sent, failed := azmail.NewMail(), azmail.NewMail()
+	mails := []*azmail.Mail{sent, failed}
+	deliveries := []database.CreateInterventionRunMailParams{
+		{Status: model.MailDeliveryStatus_Sent},
+		{Status: model.MailDeliveryStatus_Failed},
+	}
+
+	tts := []struct {
+		name              string
+		withNotifications []userNotification
+		wantParams        []database.MarkRuleEvaluationsNotifiedParams
+	}{
+		{
+			"no notifications",
+			nil,
+			nil,
+		},
+		{
+			"notification in sent mail",
+			[]userNotification{
+				{sent, "USER1", []int64{1, 2}},
+			},
+			[]database.MarkRuleEvaluationsNotifiedParams{
+				{UserID: "USER1", RuleIDs: []int64{1, 2}},
+			},
+		},
+		{
+			"notification in failed mail",
+			[]userNotification{
+				{failed, "USER1", []int64{1}},
+			},
+			nil,
+		},
+		{
+			"notifications in sent and failed mails",
+			[]userNotification{
+				{failed, "USER1", []int64{1}},
+				{sent, "USER1", []int64{2}},
+				{sent, "USER2", []int64{3}},
+			},
+			[]database.MarkRuleEvaluationsNotifiedParams{
+				{UserID: "USER1", RuleIDs: []int64{2}},
+				{UserID: "USER2", RuleIDs: []int64{3}},
+			},
+		},
+		{
+			"notification in mail that was not sent",
+			[]userNotification{
+				{azmail.NewMail(), "USER1", []int64{1}},
+			},
+			nil,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			a.Equal(tt.wantParams, deliveredNotifications(mails, deliveries, tt.withNotifications))
+		})
+	}
+}
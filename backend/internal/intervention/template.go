@@ -6,29 +6,52 @@ import (
 	texttemplate "text/template"
 
 	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 )
 
 const (
-	userEmailSubject        = "OAMS: Attendance Check Failure"
-	ruleCreatorEmailSubject = "OAMS: Attendance Check Complete"
+	userInfoEmailSubject     = "OAMS: Attendance Reminder"
+	userWarningEmailSubject  = "OAMS: Attendance Check Failure"
+	userCriticalEmailSubject = "OAMS: Critical Attendance Check Failure"
+	ruleCreatorEmailSubject  = "OAMS: Attendance Check Complete"
+	ruleQuarantineSubject    = "OAMS: Attendance Rule Quarantined"
+	globalRuleEmailSubject   = "OAMS: Global Attendance Check Complete"
 )
 
 const (
-	userTextTemplate        = "template_user_text_email.tmpl"
-	userHtmlTemplate        = "template_user_html_email.html"
-	ruleCreatorTextTemplate = "template_rule_creator_text_email.tmpl"
-	ruleCreatorHtmlTemplate = "template_rule_creator_html_email.html"
+	userInfoTextTemplate       = "template_user_info_text_email.tmpl"
+	userInfoHtmlTemplate       = "template_user_info_html_email.html"
+	userWarningTextTemplate    = "template_user_warning_text_email.tmpl"
+	userWarningHtmlTemplate    = "template_user_warning_html_email.html"
+	userCriticalTextTemplate   = "template_user_critical_text_email.tmpl"
+	userCriticalHtmlTemplate   = "template_user_critical_html_email.html"
+	ruleCreatorTextTemplate    = "template_rule_creator_text_email.tmpl"
+	ruleCreatorHtmlTemplate    = "template_rule_creator_html_email.html"
+	ruleQuarantineTextTemplate = "template_rule_quarantine_text_email.tmpl"
+	ruleQuarantineHtmlTemplate = "template_rule_quarantine_html_email.html"
+	globalRuleTextTemplate     = "template_global_rule_text_email.tmpl"
+	globalRuleHtmlTemplate     = "template_global_rule_html_email.html"
 )
 
 var (
 	//go:embed *.tmpl *.html
 	templates embed.FS
 
-	userTextEmail *texttemplate.Template
-	userHtmlEmail *htmltemplate.Template
+	// userEmails contains the subject and templates of the mail to a user for each rule severity.
+	userEmails = map[model.RuleSeverity]*userEmail{
+		model.RuleSeverity_Info:     {subject: userInfoEmailSubject, textTemplate: userInfoTextTemplate, htmlTemplate: userInfoHtmlTemplate},
+		model.RuleSeverity_Warning:  {subject: userWarningEmailSubject, textTemplate: userWarningTextTemplate, htmlTemplate: userWarningHtmlTemplate},
+		model.RuleSeverity_Critical: {subject: userCriticalEmailSubject, textTemplate: userCriticalTextTemplate, htmlTemplate: userCriticalHtmlTemplate},
+	}
 
 	ruleCreatorTextEmail *texttemplate.Template
 	ruleCreatorHtmlEmail *htmltemplate.Template
+
+	ruleQuarantineTextEmail *texttemplate.Template
+	ruleQuarantineHtmlEmail *htmltemplate.Template
+
+	globalRuleTextEmail *texttemplate.Template
+	globalRuleHtmlEmail *htmltemplate.Template
 )
 
 type userKey struct {
@@ -37,6 +60,15 @@ type userKey struct {
 	Email string
 }
 
+type userEmail struct {
+	subject      string
+	textTemplate string
+	htmlTemplate string
+
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
 type userEmailArgs struct {
 	UserInfo userKey
 	Rules    []database.RuleInfo
@@ -47,6 +79,15 @@ type ruleCreatorEmailArgs struct {
 	RuleAndUsers []ruleAndFailedUsers
 }
 
+type ruleQuarantineEmailArgs struct {
+	CreatorInfo userKey
+	Rules       []quarantinedRule
+}
+
+type globalRuleEmailArgs struct {
+	Results []globalRuleResult
+}
+
 type ruleAndFailedUsers struct {
 	Rule        database.RuleInfo
 	FailedUsers []userKey
@@ -54,22 +95,44 @@ type ruleAndFailedUsers struct {
 
 func init() {
 	var err error
-	userTextEmail, err = texttemplate.ParseFS(templates, userTextTemplate)
+	for _, email := range userEmails {
+		email.text, err = texttemplate.ParseFS(templates, email.textTemplate)
+		if err != nil {
+			panic(err)
+		}
+
+		email.html, err = htmltemplate.ParseFS(templates, email.htmlTemplate)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	ruleCreatorTextEmail, err = texttemplate.ParseFS(templates, ruleCreatorTextTemplate)
 	if err != nil {
 		panic(err)
 	}
 
-	userHtmlEmail, err = htmltemplate.ParseFS(templates, userHtmlTemplate)
+	ruleCreatorHtmlEmail, err = htmltemplate.ParseFS(templates, ruleCreatorHtmlTemplate)
 	if err != nil {
 		panic(err)
 	}
 
-	ruleCreatorTextEmail, err = texttemplate.ParseFS(templates, ruleCreatorTextTemplate)
+	ruleQuarantineTextEmail, err = texttemplate.ParseFS(templates, ruleQuarantineTextTemplate)
 	if err != nil {
 		panic(err)
 	}
 
-	ruleCreatorHtmlEmail, err = htmltemplate.ParseFS(templates, ruleCreatorHtmlTemplate)
+	ruleQuarantineHtmlEmail, err = htmltemplate.ParseFS(templates, ruleQuarantineHtmlTemplate)
+	if err != nil {
+		panic(err)
+	}
+
+	globalRuleTextEmail, err = texttemplate.ParseFS(templates, globalRuleTextTemplate)
+	if err != nil {
+		panic(err)
+	}
+
+	globalRuleHtmlEmail, err = htmltemplate.ParseFS(templates, globalRuleHtmlTemplate)
 	if err != nil {
 		panic(err)
 	}
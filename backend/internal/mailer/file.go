@@ -0,0 +1,66 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/darylhjd/azmail"
+)
+
+const (
+	// fileMailerSender is the sender address of mails written by a FileMailer.
+	fileMailerSender = "oams@localhost"
+)
+
+// FileMailer writes mails to a directory as .eml files instead of sending them. This allows the mails to be inspected
+// without a mail server.
+type FileMailer struct {
+	dir string
+}
+
+// NewFileMailer creates a FileMailer. The directory is created when mails are first written.
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{dir}
+}
+
+func (m *FileMailer) SendMails(mails ...*azmail.Mail) error {
+	if len(mails) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("%s - could not create mail sink directory: %w", Namespace, err)
+	}
+
+	var errs []error
+	for _, mail := range mails {
+		if err := m.write(mail); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (m *FileMailer) write(mail *azmail.Mail) error {
+	now := time.Now()
+
+	msg, err := buildMessage(fileMailerSender, mail, now)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(m.dir, fmt.Sprintf("%s-*.eml", now.Format("20060102T150405")))
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(msg); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darylhjd/azmail"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailer_SendMails(t *testing.T) {
+	a := assert.New(t)
+
+	dir := filepath.Join(t.TempDir(), "mails")
+	mailer := NewFileMailer(dir)
+
+	mails := []*azmail.Mail{
+		{
+			Recipients: azmail.MailRecipients{To: []azmail.MailAddress{{Address: "first@example.com", DisplayName: "First"}}},
+			Content:    azmail.MailContent{Subject: "First", PlainText: "first"},
+		},
+		{
+			Recipients: azmail.MailRecipients{To: []azmail.MailAddress{{Address: "second@example.com", DisplayName: "Second"}}},
+			Content:    azmail.MailContent{Subject: "Second", PlainText: "second"},
+		},
+	}
+
+	a.Nil(mailer.SendMails(mails...))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	a.Nil(err)
+	a.Len(files, len(mails))
+
+	subjects := make([]string, 0, len(files))
+	for _, file := range files {
+		f, err := os.Open(file)
+		a.Nil(err)
+
+		msg, err := mail.ReadMessage(f)
+		a.Nil(err)
+		subjects = append(subjects, msg.Header.Get("Subject"))
+		a.Nil(f.Close())
+	}
+
+	a.ElementsMatch([]string{"First", "Second"}, subjects)
+}
+
+func TestFileMailer_SendMailsNone(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mails")
+
+	assert.Nil(t, NewFileMailer(dir).SendMails())
+	assert.NoDirExists(t, dir)
+}
@@ -0,0 +1,51 @@
+// Package mailer provides the transports used to send mails. The transport is chosen through the MAIL_TRANSPORT
+// environment variable so that mails can be sent through Azure Communication Services, an SMTP server, or written to a
+// directory for local development and tests.
+package mailer
+
+import (
+	"fmt"
+
+	"github.com/darylhjd/azmail"
+	"github.com/darylhjd/oams/backend/internal/env"
+)
+
+const (
+	Namespace = "mailer"
+)
+
+// Mailer sends mails. Failing to send a mail does not stop later mails from being sent. The errors encountered are
+// joined and returned.
+type Mailer interface {
+	SendMails(mails ...*azmail.Mail) error
+}
+
+// New creates the Mailer for the configured mail transport.
+func New() (Mailer, error) {
+	switch transport := env.GetMailTransport(); transport {
+	case env.MailTransportAzure:
+		client, err := azmail.NewClient(
+			env.GetAzureEmailEndpoint(),
+			env.GetAzureEmailAccessKey(),
+			env.GetAzureEmailSenderAddress(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s - could not create azure mail client: %w", Namespace, err)
+		}
+
+		return client, nil
+	case env.MailTransportSMTP:
+		return NewSMTPMailer(
+			env.GetSMTPHost(),
+			env.GetSMTPPort(),
+			env.GetSMTPUsername(),
+			env.GetSMTPPassword(),
+			env.GetSMTPSenderAddress(),
+			env.GetAppEnv() != env.AppEnvLocal,
+		), nil
+	case env.MailTransportFile:
+		return NewFileMailer(env.GetMailSinkDirectory()), nil
+	default:
+		return nil, fmt.Errorf("%s - unknown mail transport %q", Namespace, transport)
+	}
+}
@@ -0,0 +1,183 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/darylhjd/azmail"
+)
+
+const (
+	// base64LineLength is the maximum length of a line of base64 encoded attachment content.
+	base64LineLength = 76
+)
+
+// buildMessage composes a mail as an RFC 5322 message. The plain text and HTML content are sent as alternatives.
+// Attachments are expected to be base64 encoded, as they are for Azure Communication Services. Bcc recipients are
+// not included in the headers.
+func buildMessage(sender string, m *azmail.Mail, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+
+	messageId, err := newMessageId(sender)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := [][2]string{
+		{"From", formatAddresses([]azmail.MailAddress{{Address: sender}})},
+		{"To", formatAddresses(m.Recipients.To)},
+		{"Cc", formatAddresses(m.Recipients.Cc)},
+		{"Subject", mime.QEncoding.Encode("UTF-8", m.Content.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", messageId},
+		{"MIME-Version", "1.0"},
+	}
+
+	for _, header := range headers {
+		if header[1] != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+		}
+	}
+
+	if len(m.Attachments) == 0 {
+		alternative := multipart.NewWriter(&buf)
+		fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", alternative.Boundary())
+		if err = writeAlternatives(alternative, m.Content); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	mixed := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mixed.Boundary())
+
+	var alternatives bytes.Buffer
+	alternative := multipart.NewWriter(&alternatives)
+	if err = writeAlternatives(alternative, m.Content); err != nil {
+		return nil, err
+	}
+
+	part, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%s", alternative.Boundary())},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = alternatives.WriteTo(part); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range m.Attachments {
+		if err = writeAttachment(mixed, attachment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = mixed.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeAlternatives writes the plain text and HTML content of a mail. The HTML alternative is left out if the mail
+// has no HTML content.
+func writeAlternatives(w *multipart.Writer, content azmail.MailContent) error {
+	alternatives := [][2]string{
+		{"text/plain", content.PlainText},
+	}
+
+	if content.Html != "" {
+		alternatives = append(alternatives, [2]string{"text/html", content.Html})
+	}
+
+	for _, alternative := range alternatives {
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("%s; charset=UTF-8", alternative[0])},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return err
+		}
+
+		qp := quotedprintable.NewWriter(part)
+		if _, err = qp.Write([]byte(alternative[1])); err != nil {
+			return err
+		}
+
+		if err = qp.Close(); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+func writeAttachment(w *multipart.Writer, attachment azmail.MailAttachment) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {attachment.ContentType},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+
+	content := attachment.Base64Content
+	for len(content) > 0 {
+		n := min(len(content), base64LineLength)
+		if _, err = fmt.Fprintf(part, "%s\r\n", content[:n]); err != nil {
+			return err
+		}
+
+		content = content[n:]
+	}
+
+	return nil
+}
+
+func formatAddresses(addresses []azmail.MailAddress) string {
+	formatted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		formatted = append(formatted, (&mail.Address{Name: address.DisplayName, Address: address.Address}).String())
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// envelopeRecipients returns the addresses of all recipients of a mail, including Bcc recipients.
+func envelopeRecipients(m *azmail.Mail) []string {
+	var recipients []string
+	for _, addresses := range [][]azmail.MailAddress{m.Recipients.To, m.Recipients.Cc, m.Recipients.Bcc} {
+		for _, address := range addresses {
+			recipients = append(recipients, address.Address)
+		}
+	}
+
+	return recipients
+}
+
+func newMessageId(sender string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	domain := "localhost"
+	if _, after, ok := strings.Cut(sender, "@"); ok && after != "" {
+		domain = after
+	}
+
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
@@ -0,0 +1,168 @@
+package mailer
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/darylhjd/azmail"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMessage(t *testing.T) {
+	tts := []struct {
+		name            string
+		withMail        *azmail.Mail
+		wantCc          string
+		wantParts       []string
+		wantAttachments []string
+	}{
+		{
+			"plain text and html",
+			&azmail.Mail{
+				Recipients: azmail.MailRecipients{
+					To:  []azmail.MailAddress{{Address: "student@example.com", DisplayName: "Student"}},
+					Cc:  []azmail.MailAddress{{Address: "coordinator@example.com", DisplayName: "Coordinator"}},
+					Bcc: []azmail.MailAddress{{Address: "archive@example.com"}},
+				},
+				Content: azmail.MailContent{
+					Subject:   "OAMS: Attendance Check Failure",
+					PlainText: "You have missed a session.",
+					Html:      "<p>You have missed a session.</p>",
+				},
+			},
+			`"Coordinator" <coordinator@example.com>`,
+			[]string{"You have missed a session.", "<p>You have missed a session.</p>"},
+			nil,
+		},
+		{
+			"plain text only",
+			&azmail.Mail{
+				Recipients: azmail.MailRecipients{
+					To: []azmail.MailAddress{{Address: "student@example.com", DisplayName: "Student"}},
+				},
+				Content: azmail.MailContent{
+					Subject:   "OAMS: Attendance Reminder",
+					PlainText: "Attendance is 75% — please attend.",
+				},
+			},
+			"",
+			[]string{"Attendance is 75% — please attend."},
+			nil,
+		},
+		{
+			"with attachment",
+			&azmail.Mail{
+				Recipients: azmail.MailRecipients{
+					To: []azmail.MailAddress{{Address: "affairs@example.com"}},
+				},
+				Content: azmail.MailContent{
+					Subject:   "OAMS: Global Attendance Check Complete",
+					PlainText: "See attached.",
+					Html:      "<p>See attached.</p>",
+				},
+				Attachments: []azmail.MailAttachment{
+					{Name: "report.csv", Base64Content: "dXNlcl9pZCxmYWlsZWQKMSx0cnVlCg==", ContentType: "text/csv"},
+				},
+			},
+			"",
+			[]string{"See attached.", "<p>See attached.</p>"},
+			[]string{"report.csv"},
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			msg, err := buildMessage("oams@example.com", tt.withMail, time.Now())
+			a.Nil(err)
+
+			parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+			a.Nil(err)
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+			a.Nil(err)
+			a.Equal(tt.withMail.Content.Subject, subject)
+
+			from, err := mail.ParseAddress(parsed.Header.Get("From"))
+			a.Nil(err)
+			a.Equal("oams@example.com", from.Address)
+
+			to, err := mail.ParseAddressList(parsed.Header.Get("To"))
+			a.Nil(err)
+			a.Equal(tt.withMail.Recipients.To[0].Address, to[0].Address)
+
+			a.Equal(tt.wantCc, parsed.Header.Get("Cc"))
+			a.Empty(parsed.Header.Get("Bcc"))
+
+			parts, attachments := readParts(t, parsed.Header.Get("Content-Type"), parsed.Body)
+			a.Equal(tt.wantParts, parts)
+			a.Equal(tt.wantAttachments, attachments)
+		})
+	}
+}
+
+func TestEnvelopeRecipients(t *testing.T) {
+	m := &azmail.Mail{
+		Recipients: azmail.MailRecipients{
+			To:  []azmail.MailAddress{{Address: "student@example.com", DisplayName: "Student"}},
+			Cc:  []azmail.MailAddress{{Address: "coordinator@example.com", DisplayName: "Coordinator"}},
+			Bcc: []azmail.MailAddress{{Address: "archive@example.com"}},
+		},
+	}
+
+	assert.Equal(
+		t,
+		[]string{"student@example.com", "coordinator@example.com", "archive@example.com"},
+		envelopeRecipients(m),
+	)
+}
+
+// readParts returns the decoded text parts and the names of the attachments of a multipart body.
+func readParts(t *testing.T, contentType string, body io.Reader) ([]string, []string) {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var parts, attachments []string
+	r := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		partType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		switch {
+		case partType == "multipart/alternative":
+			nested, _ := readParts(t, part.Header.Get("Content-Type"), part)
+			parts = append(parts, nested...)
+		case mediaType == "multipart/mixed" && part.FileName() != "":
+			attachments = append(attachments, part.FileName())
+		default:
+			// Quoted-printable parts are decoded by the reader.
+			content, err := io.ReadAll(part)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parts = append(parts, string(content))
+		}
+	}
+
+	return parts, attachments
+}
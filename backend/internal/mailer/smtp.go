@@ -0,0 +1,114 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/darylhjd/azmail"
+)
+
+// SMTPMailer sends mails through an SMTP server. The connection is upgraded with STARTTLS when the server supports it.
+// If TLS is required, mails are not sent to servers that do not support STARTTLS. Authentication is only attempted if a
+// username is given.
+type SMTPMailer struct {
+	host       string
+	port       string
+	username   string
+	password   string
+	sender     string
+	requireTLS bool
+}
+
+// NewSMTPMailer creates a SMTPMailer.
+func NewSMTPMailer(host, port, username, password, sender string, requireTLS bool) *SMTPMailer {
+	return &SMTPMailer{host, port, username, password, sender, requireTLS}
+}
+
+// SendMails sends the mails over a single connection to the SMTP server.
+func (m *SMTPMailer) SendMails(mails ...*azmail.Mail) error {
+	if len(mails) == 0 {
+		return nil
+	}
+
+	c, err := m.dial()
+	if err != nil {
+		return fmt.Errorf("%s - could not connect to smtp server: %w", Namespace, err)
+	}
+	defer c.Close()
+
+	var errs []error
+	for _, mail := range mails {
+		if err = m.send(c, mail); err == nil {
+			continue
+		}
+
+		errs = append(errs, err)
+		if err = c.Reset(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+	}
+
+	if err = c.Quit(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (m *SMTPMailer) dial() (*smtp.Client, error) {
+	c, err := smtp.Dial(net.JoinHostPort(m.host, m.port))
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			c.Close()
+			return nil, err
+		}
+	} else if m.requireTLS {
+		c.Close()
+		return nil, errors.New("server does not support STARTTLS")
+	}
+
+	if m.username != "" {
+		if err = c.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (m *SMTPMailer) send(c *smtp.Client, mail *azmail.Mail) error {
+	msg, err := buildMessage(m.sender, mail, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err = c.Mail(m.sender); err != nil {
+		return err
+	}
+
+	for _, recipient := range envelopeRecipients(mail) {
+		if err = c.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
@@ -0,0 +1,14 @@
+package rules
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+func (d *RuleDefinition) Scan(value any) error {
+	return json.Unmarshal(value.([]byte), d)
+}
+
+func (d RuleDefinition) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
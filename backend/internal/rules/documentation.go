@@ -0,0 +1,49 @@
+package rules
+
+// Documentation describes the environment that advanced rules are evaluated in.
+type Documentation struct {
+	Variables  []Variable `json:"variables"`
+	FactFields []Variable `json:"fact_fields"`
+	Functions  []Function `json:"functions"`
+}
+
+// Variable describes a variable in the rule environment or a field of a Fact.
+type Variable struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+var variables = []Variable{
+	{"enrollments", "[]Fact", "The facts of the sessions of a student in a class, ordered by session start time. Excused sessions are excluded."},
+}
+
+var factFields = []Variable{
+	{"ClassID", "int", "The ID of the class."},
+	{"ClassCode", "string", "The code of the class."},
+	{"ClassYear", "int", "The year of the class."},
+	{"ClassSemester", "string", "The semester of the class."},
+	{"ClassStartTime", "time", "The start time of the first session of the class."},
+	{"ClassGroupID", "int", "The ID of the class group of the session."},
+	{"ClassGroupName", "string", "The name of the class group of the session."},
+	{"ClassType", "string", "The class type of the class group of the session. One of LEC, TUT or LAB."},
+	{"StartTime", "time", "The start time of the session."},
+	{"EndTime", "time", "The end time of the session."},
+	{"Venue", "string", "The venue of the session."},
+	{"UserID", "string", "The ID of the student."},
+	{"UserName", "string", "The name of the student."},
+	{"UserEmail", "string", "The email of the student."},
+	{"Status", "string", "The attendance status of the student. One of PRESENT, LATE or ABSENT."},
+	{"Attended", "bool", "Whether the student was present or late."},
+	{"SessionIndex", "int", "The position of the session among the sessions of the student in the class, starting from 1."},
+	{"TeachingWeek", "int", "The week of the session counted from the week of the first session of the class, starting from 1."},
+}
+
+// Describe returns the Documentation of the rule environment.
+func Describe() Documentation {
+	return Documentation{
+		Variables:  variables,
+		FactFields: factFields,
+		Functions:  functions,
+	}
+}
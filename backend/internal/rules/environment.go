@@ -7,6 +7,7 @@ const (
 	TConsecutive T = iota
 	TPercentage
 	TAdvanced
+	TLateCount
 )
 
 // E is an interface that all environments for any rule must satisfy.
@@ -27,21 +28,34 @@ func (e BaseE) SetFacts(facts []Fact) E {
 
 type ConsecutiveE struct {
 	BaseE
+	FilterE
 	ConsecutiveClasses int `expr:"consecutive_classes" json:"consecutive_classes"`
 }
 
 func (e ConsecutiveE) SetFacts(facts []Fact) E {
-	e.Enrollments = facts
+	e.Enrollments = e.FilterE.apply(facts)
 	return e
 }
 
 type PercentageE struct {
 	BaseE
+	FilterE
 	Percentage  float64 `expr:"percentage" json:"percentage"`
 	FromSession int     `expr:"from_session" json:"from_session"`
 }
 
 func (e PercentageE) SetFacts(facts []Fact) E {
-	e.Enrollments = facts
+	e.Enrollments = e.FilterE.apply(facts)
+	return e
+}
+
+type LateCountE struct {
+	BaseE
+	FilterE
+	LateCount int `expr:"late_count" json:"late_count"`
+}
+
+func (e LateCountE) SetFacts(facts []Fact) E {
+	e.Enrollments = e.FilterE.apply(facts)
 	return e
 }
@@ -30,6 +30,10 @@ func (e *Environment) Scan(value any) error {
 		e.Env = &BaseE{
 			EnvType: t.EnvType,
 		}
+	case TLateCount:
+		e.Env = &LateCountE{
+			BaseE: BaseE{EnvType: t.EnvType},
+		}
 	default:
 		return errors.New("unknown rule environment type")
 	}
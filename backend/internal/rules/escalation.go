@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+)
+
+// EscalationPolicy decides who else is notified when a student triggers a rule, based on the number of times the
+// student has been notified of the rule. The student is always notified.
+type EscalationPolicy struct {
+	Steps []EscalationStep `json:"steps"`
+}
+
+// EscalationStep applies from a notification of a student onwards, until a later step applies. Notifications are
+// counted from 1.
+type EscalationStep struct {
+	FromNotification int    `json:"from_notification"`
+	CCCoordinator    bool   `json:"cc_coordinator"`
+	AdvisorEmail     string `json:"advisor_email"`
+}
+
+// Verify checks that the steps of the policy are in increasing order of notifications, and that advisor emails are
+// valid.
+func (p EscalationPolicy) Verify() error {
+	last := 0
+	for _, step := range p.Steps {
+		if step.FromNotification <= last {
+			return fmt.Errorf("escalation step notifications must be increasing and start from 1, got %d", step.FromNotification)
+		}
+
+		if step.AdvisorEmail != "" {
+			if _, err := mail.ParseAddress(step.AdvisorEmail); err != nil {
+				return fmt.Errorf("invalid advisor email %q", step.AdvisorEmail)
+			}
+		}
+
+		last = step.FromNotification
+	}
+
+	return nil
+}
+
+// StepFor returns the step that applies to a notification. If no step applies, only the student is notified.
+func (p EscalationPolicy) StepFor(notification int) EscalationStep {
+	var res EscalationStep
+	for _, step := range p.Steps {
+		if step.FromNotification <= notification {
+			res = step
+		}
+	}
+
+	return res
+}
+
+func (p *EscalationPolicy) Scan(value any) error {
+	return json.Unmarshal(value.([]byte), p)
+}
+
+func (p EscalationPolicy) Value() (driver.Value, error) {
+	if p.Steps == nil {
+		p.Steps = []EscalationStep{}
+	}
+
+	return json.Marshal(p)
+}
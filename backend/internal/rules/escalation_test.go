@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscalationPolicy_Verify(t *testing.T) {
+	tts := []struct {
+		name       string
+		withPolicy EscalationPolicy
+		wantErr    bool
+	}{
+		{
+			"empty policy",
+			EscalationPolicy{},
+			false,
+		},
+		{
+			"increasing steps",
+			EscalationPolicy{[]EscalationStep{
+				{FromNotification: 2, CCCoordinator: true},
+				{FromNotification: 3, CCCoordinator: true, AdvisorEmail: "advisor@example.com"},
+			}},
+			false,
+		},
+		{
+			"step before first notification",
+			EscalationPolicy{[]EscalationStep{{FromNotification: 0, CCCoordinator: true}}},
+			true,
+		},
+		{
+			"repeated step",
+			EscalationPolicy{[]EscalationStep{
+				{FromNotification: 2, CCCoordinator: true},
+				{FromNotification: 2, AdvisorEmail: "advisor@example.com"},
+			}},
+			true,
+		},
+		{
+			"invalid advisor email",
+			EscalationPolicy{[]EscalationStep{{FromNotification: 1, AdvisorEmail: "advisor"}}},
+			true,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.withPolicy.Verify()
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestEscalationPolicy_StepFor(t *testing.T) {
+	a := assert.New(t)
+
+	cc := EscalationStep{FromNotification: 2, CCCoordinator: true}
+	advisor := EscalationStep{FromNotification: 3, CCCoordinator: true, AdvisorEmail: "advisor@example.com"}
+	policy := EscalationPolicy{[]EscalationStep{cc, advisor}}
+
+	a.Equal(EscalationStep{}, policy.StepFor(1))
+	a.Equal(cc, policy.StepFor(2))
+	a.Equal(advisor, policy.StepFor(3))
+	a.Equal(advisor, policy.StepFor(10))
+}
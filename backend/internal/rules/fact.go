@@ -1,20 +1,77 @@
 package rules
 
-import "time"
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+)
 
 // Fact represents a session attendance fact. Each fact corresponds to one session. Additional useful information is also
 // contained within to allow the user to generate custom rules.
+//
+// Status is one of PRESENT, LATE, ABSENT or EXCUSED. Attended is true if the student was present or late.
+//
+// ClassStartTime is the start time of the first session of the class. SessionIndex is the position of the session
+// among the sessions of the student in the class, starting from 1. TeachingWeek is the week of the session counted
+// from the week of the first session of the class, starting from 1. These are set by AnnotateFacts.
 type Fact struct {
-	ClassID       int64     `alias:"class.id"`
-	ClassCode     string    `alias:"class.code"`
-	ClassYear     int32     `alias:"class.year"`
-	ClassSemester string    `alias:"class.semester"`
-	ClassType     string    `alias:"class_group.class_type"`
-	StartTime     time.Time `alias:"class_group_session.start_time"`
-	EndTime       time.Time `alias:"class_group_session.end_time"`
-	Venue         string    `alias:"class_group_session.venue"`
-	UserID        string    `alias:"session_enrollment.user_id"`
-	UserName      string    `alias:"user.name"`
-	UserEmail     string    `alias:"user.email"`
-	Attended      bool      `alias:"session_enrollment.attended"`
+	ClassID        int64     `alias:"class.id"`
+	ClassCode      string    `alias:"class.code"`
+	ClassYear      int32     `alias:"class.year"`
+	ClassSemester  string    `alias:"class.semester"`
+	ClassStartTime time.Time `alias:"class.start_time"`
+	ClassGroupID   int64     `alias:"class_group.id"`
+	ClassGroupName string    `alias:"class_group.name"`
+	ClassType      string    `alias:"class_group.class_type"`
+	StartTime      time.Time `alias:"class_group_session.start_time"`
+	EndTime        time.Time `alias:"class_group_session.end_time"`
+	Venue          string    `alias:"class_group_session.venue"`
+	UserID         string    `alias:"session_enrollment.user_id"`
+	UserName       string    `alias:"user.name"`
+	UserEmail      string    `alias:"user.email"`
+	Status         string    `alias:"session_enrollment.status"`
+	Attended       bool      `alias:"session_enrollment.attended"`
+	SessionIndex   int
+	TeachingWeek   int
+}
+
+// AnnotateFacts sets the SessionIndex and TeachingWeek of each fact. The order of the facts is not changed.
+func AnnotateFacts(facts []Fact) {
+	type classUserKey struct {
+		ClassID int64
+		UserID  string
+	}
+
+	groups := map[classUserKey][]*Fact{}
+	for idx := range facts {
+		fact := &facts[idx]
+		fact.TeachingWeek = teachingWeek(fact.ClassStartTime, fact.StartTime)
+
+		key := classUserKey{fact.ClassID, fact.UserID}
+		groups[key] = append(groups[key], fact)
+	}
+
+	for _, group := range groups {
+		slices.SortStableFunc(group, func(a, b *Fact) int {
+			return cmp.Or(a.StartTime.Compare(b.StartTime), a.EndTime.Compare(b.EndTime))
+		})
+
+		for idx, fact := range group {
+			fact.SessionIndex = idx + 1
+		}
+	}
+}
+
+// teachingWeek returns the week of a session counted from the week of the first session of its class. Weeks start on
+// Monday.
+func teachingWeek(classStart, sessionStart time.Time) int {
+	weekStart := func(t time.Time) time.Time {
+		t = t.In(datetime.Location)
+		return time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, datetime.Location)
+	}
+
+	days := weekStart(sessionStart).Sub(weekStart(classStart)).Round(24*time.Hour) / (24 * time.Hour)
+	return int(days)/7 + 1
 }
@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	classTypeLec = "LEC"
+	classTypeTut = "TUT"
+	classTypeLab = "LAB"
+)
+
+// FilterE restricts the facts that a built-in rule is evaluated against. A zero value field does not restrict facts.
+type FilterE struct {
+	ClassTypes []string   `expr:"class_types" json:"class_types,omitempty"`
+	StartTime  *time.Time `expr:"start_time" json:"start_time,omitempty"`
+	EndTime    *time.Time `expr:"end_time" json:"end_time,omitempty"`
+}
+
+// apply returns the facts of sessions that are of one of the class types and start within the date range.
+func (f FilterE) apply(facts []Fact) []Fact {
+	if len(f.ClassTypes) == 0 && f.StartTime == nil && f.EndTime == nil {
+		return facts
+	}
+
+	res := make([]Fact, 0, len(facts))
+	for _, fact := range facts {
+		switch {
+		case len(f.ClassTypes) > 0 && !slices.Contains(f.ClassTypes, fact.ClassType):
+		case f.StartTime != nil && fact.StartTime.Before(*f.StartTime):
+		case f.EndTime != nil && !fact.StartTime.Before(*f.EndTime):
+		default:
+			res = append(res, fact)
+		}
+	}
+
+	return res
+}
+
+type filterParams struct {
+	ClassTypes []string `json:"class_types"`
+	StartTime  int64    `json:"start_time"`
+	EndTime    int64    `json:"end_time"`
+}
+
+func (p filterParams) isEmpty() bool {
+	return len(p.ClassTypes) == 0 && p.StartTime == 0 && p.EndTime == 0
+}
+
+// verify checks the filter parameters and converts them to a FilterE. Start and end times are Unix timestamps in
+// milliseconds, and a zero value leaves that end of the date range open.
+func (p filterParams) verify() (FilterE, error) {
+	var filter FilterE
+
+	for _, classType := range p.ClassTypes {
+		switch classType {
+		case classTypeLec, classTypeTut, classTypeLab:
+		default:
+			return filter, fmt.Errorf("unknown class type %q", classType)
+		}
+
+		if !slices.Contains(filter.ClassTypes, classType) {
+			filter.ClassTypes = append(filter.ClassTypes, classType)
+		}
+	}
+
+	if p.StartTime != 0 {
+		startTime := time.UnixMilli(p.StartTime)
+		filter.StartTime = &startTime
+	}
+
+	if p.EndTime != 0 {
+		endTime := time.UnixMilli(p.EndTime)
+		filter.EndTime = &endTime
+	}
+
+	if filter.StartTime != nil && filter.EndTime != nil && !filter.StartTime.Before(*filter.EndTime) {
+		return filter, errors.New("filter start time must be before end time")
+	}
+
+	return filter, nil
+}
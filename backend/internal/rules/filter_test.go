@@ -0,0 +1,167 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterE_apply(t *testing.T) {
+	week1 := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	week3 := week2.AddDate(0, 0, 7)
+
+	lec1 := Fact{ClassType: "LEC", StartTime: week1}
+	tut1 := Fact{ClassType: "TUT", StartTime: week1}
+	tut2 := Fact{ClassType: "TUT", StartTime: week2}
+	lab3 := Fact{ClassType: "LAB", StartTime: week3}
+	facts := []Fact{lec1, tut1, tut2, lab3}
+
+	tts := []struct {
+		name       string
+		withFilter FilterE
+		wantFacts  []Fact
+	}{
+		{
+			"empty filter",
+			FilterE{},
+			facts,
+		},
+		{
+			"class types",
+			FilterE{ClassTypes: []string{"TUT", "LAB"}},
+			[]Fact{tut1, tut2, lab3},
+		},
+		{
+			"start time is inclusive",
+			FilterE{StartTime: &week2},
+			[]Fact{tut2, lab3},
+		},
+		{
+			"end time is exclusive",
+			FilterE{EndTime: &week2},
+			[]Fact{lec1, tut1},
+		},
+		{
+			"class types and date range",
+			FilterE{ClassTypes: []string{"TUT"}, StartTime: &week2, EndTime: &week3},
+			[]Fact{tut2},
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			a.Equal(tt.wantFacts, tt.withFilter.apply(facts))
+		})
+	}
+}
+
+func TestRuleParams_Verify_filter(t *testing.T) {
+	base := RuleParams{
+		Title:       "title",
+		Description: "description",
+		RuleDefinition: RuleDefinition{
+			ConsecutiveParams: consecutiveParams{ConsecutiveClasses: 2},
+		},
+	}
+
+	tts := []struct {
+		name         string
+		withFilter   filterParams
+		withRuleType T
+		wantErr      bool
+	}{
+		{
+			"valid filter",
+			filterParams{ClassTypes: []string{"TUT"}, StartTime: 1000, EndTime: 2000},
+			TConsecutive,
+			false,
+		},
+		{
+			"unknown class type",
+			filterParams{ClassTypes: []string{"SEM"}},
+			TConsecutive,
+			true,
+		},
+		{
+			"start time not before end time",
+			filterParams{StartTime: 2000, EndTime: 2000},
+			TConsecutive,
+			true,
+		},
+		{
+			"filter on advanced rule",
+			filterParams{ClassTypes: []string{"TUT"}},
+			TAdvanced,
+			true,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			params := base
+			params.RuleType = tt.withRuleType
+			params.AdvancedParams.Rule = "true"
+			params.FilterParams = tt.withFilter
+
+			_, _, err := params.Verify()
+			a.Equal(tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestLateCountRule(t *testing.T) {
+	late := Fact{ClassType: "LEC", Status: "LATE", Attended: true}
+	tutLate := Fact{ClassType: "TUT", Status: "LATE", Attended: true}
+	present := Fact{ClassType: "LEC", Status: "PRESENT", Attended: true}
+
+	tts := []struct {
+		name          string
+		withParams    RuleDefinition
+		withFacts     []Fact
+		wantTriggered bool
+	}{
+		{
+			"late count reached",
+			RuleDefinition{LateCountParams: lateCountParams{LateCount: 2}},
+			[]Fact{late, present, tutLate},
+			true,
+		},
+		{
+			"late count not reached",
+			RuleDefinition{LateCountParams: lateCountParams{LateCount: 3}},
+			[]Fact{late, present, tutLate},
+			false,
+		},
+		{
+			"late count with class type filter",
+			RuleDefinition{
+				LateCountParams: lateCountParams{LateCount: 2},
+				FilterParams:    filterParams{ClassTypes: []string{"LEC"}},
+			},
+			[]Fact{late, present, tutLate},
+			false,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			params := RuleParams{"title", "description", tt.withParams}
+			params.RuleType = TLateCount
+
+			rule, env, err := params.Verify()
+			a.Nil(err)
+
+			res, err := expr.Eval(rule, env.SetFacts(tt.withFacts))
+			a.Nil(err)
+			a.Equal(tt.wantTriggered, res)
+		})
+	}
+}
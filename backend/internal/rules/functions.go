@@ -0,0 +1,214 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Function describes a helper function that is available to rules.
+type Function struct {
+	Name        string `json:"name"`
+	Signature   string `json:"signature"`
+	Description string `json:"description"`
+
+	fn    func(params ...any) (any, error)
+	types []any
+}
+
+// functions are the helper functions available to rules. Functions that take facts accept both the enrollments
+// variable and the result of filtering it, such as filter(enrollments, {.ClassType == "LAB"}).
+var functions = []Function{
+	{
+		Name:        "longest_absence_streak",
+		Signature:   "longest_absence_streak(facts) int",
+		Description: "Returns the largest number of sessions missed in a row.",
+		fn: factsFunc(func(facts []Fact) any {
+			longest, current := 0, 0
+			for _, fact := range facts {
+				current = absenceStreak(current, fact)
+				longest = max(longest, current)
+			}
+
+			return longest
+		}),
+		types: []any{
+			new(func([]Fact) int),
+			new(func([]any) int),
+		},
+	},
+	{
+		Name:        "current_absence_streak",
+		Signature:   "current_absence_streak(facts) int",
+		Description: "Returns the number of sessions missed in a row up to the latest session.",
+		fn: factsFunc(func(facts []Fact) any {
+			current := 0
+			for _, fact := range facts {
+				current = absenceStreak(current, fact)
+			}
+
+			return current
+		}),
+		types: []any{
+			new(func([]Fact) int),
+			new(func([]any) int),
+		},
+	},
+	{
+		Name:        "attendance_rate",
+		Signature:   "attendance_rate(facts) float",
+		Description: "Returns the percentage of sessions attended, from 0 to 100. Returns 100 if there are no sessions.",
+		fn: factsFunc(func(facts []Fact) any {
+			return attendanceRate(facts)
+		}),
+		types: []any{
+			new(func([]Fact) float64),
+			new(func([]any) float64),
+		},
+	},
+	{
+		Name:        "attendance_by_class_type",
+		Signature:   "attendance_by_class_type(facts) map[string]float",
+		Description: "Returns the percentage of sessions attended for each class type, such as LEC, TUT and LAB.",
+		fn: factsFunc(func(facts []Fact) any {
+			return attendanceBy(facts, func(fact Fact) string { return fact.ClassType })
+		}),
+		types: []any{
+			new(func([]Fact) map[string]float64),
+			new(func([]any) map[string]float64),
+		},
+	},
+	{
+		Name:        "attendance_by_week",
+		Signature:   "attendance_by_week(facts) map[int]float",
+		Description: "Returns the percentage of sessions attended for each teaching week.",
+		fn: factsFunc(func(facts []Fact) any {
+			return attendanceBy(facts, func(fact Fact) int { return fact.TeachingWeek })
+		}),
+		types: []any{
+			new(func([]Fact) map[int]float64),
+			new(func([]any) map[int]float64),
+		},
+	},
+	{
+		Name:        "absences_since",
+		Signature:   "absences_since(facts, time) int",
+		Description: "Returns the number of sessions missed that start at or after a time, such as date(\"2024-01-15\").",
+		fn: func(params ...any) (any, error) {
+			facts, err := toFacts(params[0])
+			if err != nil {
+				return nil, err
+			}
+
+			since := params[1].(time.Time)
+			return countFacts(facts, func(fact Fact) bool {
+				return !fact.Attended && !fact.StartTime.Before(since)
+			}), nil
+		},
+		types: []any{
+			new(func([]Fact, time.Time) int),
+			new(func([]any, time.Time) int),
+		},
+	},
+	{
+		Name:        "count_status",
+		Signature:   "count_status(facts, status) int",
+		Description: "Returns the number of sessions with a status of PRESENT, LATE or ABSENT.",
+		fn: func(params ...any) (any, error) {
+			facts, err := toFacts(params[0])
+			if err != nil {
+				return nil, err
+			}
+
+			status := params[1].(string)
+			return countFacts(facts, func(fact Fact) bool {
+				return fact.Status == status
+			}), nil
+		},
+		types: []any{
+			new(func([]Fact, string) int),
+			new(func([]any, string) int),
+		},
+	},
+}
+
+// factsFunc wraps a function that takes facts in chronological order so that it can be used as a helper function.
+func factsFunc(f func([]Fact) any) func(params ...any) (any, error) {
+	return func(params ...any) (any, error) {
+		facts, err := toFacts(params[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return f(facts), nil
+	}
+}
+
+// toFacts converts a helper function argument to facts sorted by session start time.
+func toFacts(param any) ([]Fact, error) {
+	var facts []Fact
+
+	switch p := param.(type) {
+	case []Fact:
+		facts = slices.Clone(p)
+	case []any:
+		facts = make([]Fact, 0, len(p))
+		for _, item := range p {
+			fact, ok := item.(Fact)
+			if !ok {
+				return nil, fmt.Errorf("expected facts, got %T", item)
+			}
+
+			facts = append(facts, fact)
+		}
+	default:
+		return nil, fmt.Errorf("expected facts, got %T", param)
+	}
+
+	slices.SortStableFunc(facts, func(a, b Fact) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	return facts, nil
+}
+
+func absenceStreak(current int, fact Fact) int {
+	if fact.Attended {
+		return 0
+	}
+
+	return current + 1
+}
+
+func attendanceRate(facts []Fact) float64 {
+	if len(facts) == 0 {
+		return 100
+	}
+
+	return float64(countFacts(facts, func(fact Fact) bool { return fact.Attended })) / float64(len(facts)) * 100
+}
+
+func attendanceBy[K comparable](facts []Fact, key func(Fact) K) map[K]float64 {
+	groups := map[K][]Fact{}
+	for _, fact := range facts {
+		groups[key(fact)] = append(groups[key(fact)], fact)
+	}
+
+	res := make(map[K]float64, len(groups))
+	for k, group := range groups {
+		res[k] = attendanceRate(group)
+	}
+
+	return res
+}
+
+func countFacts(facts []Fact, predicate func(Fact) bool) int {
+	count := 0
+	for _, fact := range facts {
+		if predicate(fact) {
+			count++
+		}
+	}
+
+	return count
+}
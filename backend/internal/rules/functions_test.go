@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile_functions(t *testing.T) {
+	week1 := time.Date(2024, time.January, 15, 10, 0, 0, 0, datetime.Location)
+	week2 := week1.AddDate(0, 0, 7)
+	week3 := week2.AddDate(0, 0, 7)
+
+	facts := []Fact{
+		{ClassType: "LEC", StartTime: week1, TeachingWeek: 1, Status: "PRESENT", Attended: true},
+		{ClassType: "TUT", StartTime: week1.Add(time.Hour), TeachingWeek: 1, Status: "ABSENT"},
+		{ClassType: "LEC", StartTime: week2, TeachingWeek: 2, Status: "ABSENT"},
+		{ClassType: "TUT", StartTime: week2.Add(time.Hour), TeachingWeek: 2, Status: "ABSENT"},
+		{ClassType: "LEC", StartTime: week3, TeachingWeek: 3, Status: "LATE", Attended: true},
+		{ClassType: "TUT", StartTime: week3.Add(time.Hour), TeachingWeek: 3, Status: "ABSENT"},
+	}
+
+	tts := []struct {
+		name          string
+		withRule      string
+		wantTriggered bool
+	}{
+		{
+			"longest absence streak",
+			"longest_absence_streak(enrollments) == 3",
+			true,
+		},
+		{
+			"current absence streak",
+			"current_absence_streak(enrollments) == 1",
+			true,
+		},
+		{
+			"absence streak of filtered facts",
+			`longest_absence_streak(filter(enrollments, {.ClassType == "TUT"})) == 3`,
+			true,
+		},
+		{
+			"attendance rate",
+			"attendance_rate(enrollments) < 50",
+			true,
+		},
+		{
+			"attendance by class type",
+			`attendance_by_class_type(enrollments)["LEC"] > 60 && attendance_by_class_type(enrollments)["TUT"] == 0`,
+			true,
+		},
+		{
+			"attendance by week",
+			"attendance_by_week(enrollments)[2] == 0 && attendance_by_week(enrollments)[3] == 50",
+			true,
+		},
+		{
+			"absences since",
+			`absences_since(enrollments, date("2024-01-22")) == 3`,
+			true,
+		},
+		{
+			"count status",
+			`count_status(enrollments, "LATE") > 1`,
+			false,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			env := BaseE{EnvType: TAdvanced}
+
+			prg, err := Compile(tt.withRule, env)
+			a.Nil(err)
+
+			res, err := prg.Run(env.SetFacts(facts))
+			a.Nil(err)
+			a.Equal(tt.wantTriggered, res)
+		})
+	}
+}
+
+func TestCompile_invalidArgument(t *testing.T) {
+	_, err := Compile("longest_absence_streak(1) > 0", BaseE{EnvType: TAdvanced})
+	assert.NotNil(t, err)
+}
+
+func TestAnnotateFacts(t *testing.T) {
+	a := assert.New(t)
+
+	// The first session of the class is on a Wednesday.
+	classStart := time.Date(2024, time.January, 17, 10, 0, 0, 0, datetime.Location)
+	fact := func(userId string, start time.Time) Fact {
+		return Fact{ClassID: 1, ClassStartTime: classStart, UserID: userId, StartTime: start}
+	}
+
+	facts := []Fact{
+		fact("a", classStart.AddDate(0, 0, 5)),
+		fact("a", classStart),
+		fact("b", classStart.AddDate(0, 0, 14)),
+		fact("a", classStart.AddDate(0, 0, -2)),
+	}
+
+	AnnotateFacts(facts)
+
+	a.Equal([]int{3, 2, 1, 1}, []int{facts[0].SessionIndex, facts[1].SessionIndex, facts[2].SessionIndex, facts[3].SessionIndex})
+	a.Equal([]int{2, 1, 3, 1}, []int{facts[0].TeachingWeek, facts[1].TeachingWeek, facts[2].TeachingWeek, facts[3].TeachingWeek})
+}
+
+func TestDescribe(t *testing.T) {
+	a := assert.New(t)
+
+	documented := map[string]bool{}
+	for _, field := range Describe().FactFields {
+		documented[field.Name] = true
+	}
+
+	factType := reflect.TypeOf(Fact{})
+	for i := range factType.NumField() {
+		a.True(documented[factType.Field(i).Name], "fact field %s is not documented", factType.Field(i).Name)
+	}
+
+	a.Len(Describe().FactFields, factType.NumField())
+}
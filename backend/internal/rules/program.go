@@ -0,0 +1,242 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/expr-lang/expr/vm"
+)
+
+const (
+	// maxRuleNodes is the maximum number of nodes in the syntax tree of a rule.
+	maxRuleNodes = 500
+	// maxRuleSteps is the maximum number of predicate evaluations in a single run of a rule.
+	maxRuleSteps = 1_000_000
+	// maxRuleMemory is the maximum number of elements that a single run of a rule can allocate.
+	maxRuleMemory = 100_000
+	// ruleTimeout is the maximum duration of a single run of a rule.
+	ruleTimeout = time.Second
+	// ruleTimeoutCheckInterval is the number of steps between checks of the rule timeout.
+	ruleTimeoutCheckInterval = 1000
+	// stepFunction is the name of the function that each predicate of a rule is wrapped in to count steps.
+	stepFunction = "$step"
+	// memoryFunction is the name of the function that each array or map created by a rule is wrapped in to count
+	// memory.
+	memoryFunction = "$memory"
+)
+
+// deniedFunctions are builtin functions that cannot be used in rules, as they are not needed to check attendance
+// and can be used to allocate large amounts of memory or make a rule non-deterministic.
+var deniedFunctions = map[string]bool{
+	"repeat":     true,
+	"now":        true,
+	"toJSON":     true,
+	"fromJSON":   true,
+	"toBase64":   true,
+	"fromBase64": true,
+}
+
+// LimitError is returned when a run of a rule exceeds the limit of steps, memory or duration. Unlike other errors, it
+// depends on the facts that the rule is run against and on the load at the time of the run.
+type LimitError struct {
+	msg string
+}
+
+func (e *LimitError) Error() string {
+	return e.msg
+}
+
+// Program is a compiled rule. Each run of a Program is limited in the number of steps it takes, the memory it
+// allocates and its duration. A Program must not be run concurrently.
+type Program struct {
+	prg *vm.Program
+
+	steps    int
+	memory   int
+	deadline time.Time
+}
+
+// Compile checks a rule and compiles it against an environment, with all helper functions available. A rule must
+// reference the enrollments variable, must not use denied functions and must not exceed the maximum number of nodes.
+func Compile(rule string, env E) (*Program, error) {
+	if err := analyse(rule); err != nil {
+		return nil, err
+	}
+
+	p := &Program{}
+	options := []expr.Option{
+		expr.AsBool(),
+		expr.Env(env),
+		expr.Function(stepFunction, p.step),
+		expr.Function(memoryFunction, p.allocate),
+		expr.Patch(stepPatcher{}),
+		expr.Patch(memoryPatcher{}),
+	}
+
+	for _, f := range functions {
+		options = append(options, expr.Function(f.Name, f.fn, f.types...))
+	}
+
+	prg, err := expr.Compile(rule, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.prg = prg
+	return p, nil
+}
+
+// Run runs the program against an environment and returns whether the rule is triggered. A *LimitError is returned if
+// the run exceeds a limit. The duration is checked while predicates are evaluated and arrays or maps are created, and
+// once more after the run, so that a run that took too long is never accepted.
+func (p *Program) Run(env E) (bool, error) {
+	p.steps, p.memory, p.deadline = 0, 0, time.Now().Add(ruleTimeout)
+
+	res, err := vm.Run(p.prg, env)
+	if err != nil {
+		return false, err
+	}
+
+	if err = p.checkDeadline(); err != nil {
+		return false, err
+	}
+
+	return res.(bool), nil
+}
+
+// step counts a predicate evaluation and returns its result unchanged.
+func (p *Program) step(params ...any) (any, error) {
+	p.steps++
+
+	switch {
+	case p.steps > maxRuleSteps:
+		return nil, &LimitError{fmt.Sprintf("rule exceeded the limit of %d steps", maxRuleSteps)}
+	case p.steps%ruleTimeoutCheckInterval == 0:
+		if err := p.checkDeadline(); err != nil {
+			return nil, err
+		}
+	}
+
+	return params[0], nil
+}
+
+// allocate counts the elements of a created array or map and returns it unchanged.
+func (p *Program) allocate(params ...any) (any, error) {
+	if v := reflect.ValueOf(params[0]); v.Kind() == reflect.Slice || v.Kind() == reflect.Array || v.Kind() == reflect.Map {
+		p.memory += v.Len()
+	}
+
+	if p.memory > maxRuleMemory {
+		return nil, &LimitError{fmt.Sprintf("memory budget exceeded: rule allocated more than %d elements", maxRuleMemory)}
+	}
+
+	if err := p.checkDeadline(); err != nil {
+		return nil, err
+	}
+
+	return params[0], nil
+}
+
+// checkDeadline returns an error if the current run has exceeded the time limit.
+func (p *Program) checkDeadline() error {
+	if time.Now().After(p.deadline) {
+		return &LimitError{fmt.Sprintf("rule exceeded the time limit of %s", ruleTimeout)}
+	}
+
+	return nil
+}
+
+// stepPatcher wraps the body of each predicate in a call to the step function.
+type stepPatcher struct{}
+
+func (stepPatcher) Visit(node *ast.Node) {
+	closure, ok := (*node).(*ast.ClosureNode)
+	if !ok {
+		return
+	}
+
+	ast.Patch(&closure.Node, &ast.CallNode{
+		Callee:    &ast.IdentifierNode{Value: stepFunction},
+		Arguments: []ast.Node{closure.Node},
+	})
+}
+
+// memoryPatcher wraps each node that creates an array or map in a call to the memory function. Nodes that only access
+// existing arrays or maps are not wrapped.
+type memoryPatcher struct{}
+
+func (memoryPatcher) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.ArrayNode, *ast.MapNode, *ast.BuiltinNode:
+	case *ast.BinaryNode:
+		if n.Operator != ".." {
+			return
+		}
+	case *ast.CallNode:
+		if callee, ok := n.Callee.(*ast.IdentifierNode); ok && (callee.Value == stepFunction || callee.Value == memoryFunction) {
+			return
+		}
+	default:
+		return
+	}
+
+	if t := (*node).Type(); t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map) {
+		return
+	}
+
+	ast.Patch(node, &ast.CallNode{
+		Callee:    &ast.IdentifierNode{Value: memoryFunction},
+		Arguments: []ast.Node{*node},
+	})
+}
+
+// ruleAnalyser collects information on the syntax tree of a rule.
+type ruleAnalyser struct {
+	nodes                 int
+	referencesEnrollments bool
+	deniedFunction        string
+}
+
+func (a *ruleAnalyser) Visit(node *ast.Node) {
+	a.nodes++
+
+	var name string
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode:
+		name = n.Value
+		a.referencesEnrollments = a.referencesEnrollments || n.Value == "enrollments"
+	case *ast.BuiltinNode:
+		name = n.Name
+	}
+
+	if deniedFunctions[name] && a.deniedFunction == "" {
+		a.deniedFunction = name
+	}
+}
+
+// analyse statically checks a rule before it is compiled.
+func analyse(rule string) error {
+	tree, err := parser.Parse(rule)
+	if err != nil {
+		return err
+	}
+
+	var a ruleAnalyser
+	ast.Walk(&tree.Node, &a)
+
+	switch {
+	case a.nodes > maxRuleNodes:
+		return fmt.Errorf("rule has %d nodes, which is more than the limit of %d", a.nodes, maxRuleNodes)
+	case a.deniedFunction != "":
+		return fmt.Errorf("function %s is not allowed in rules", a.deniedFunction)
+	case !a.referencesEnrollments:
+		return errors.New("rule does not reference enrollments")
+	}
+
+	return nil
+}
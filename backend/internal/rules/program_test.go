@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/expr-lang/expr/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile_analysis(t *testing.T) {
+	tts := []struct {
+		name     string
+		withRule string
+		wantErr  string
+	}{
+		{
+			"valid rule",
+			"len(enrollments) > 3",
+			"",
+		},
+		{
+			"too many nodes",
+			"len(enrollments) > 0" + strings.Repeat(" && true", maxRuleNodes),
+			"more than the limit",
+		},
+		{
+			"denied function",
+			`len(enrollments) > 0 && len(repeat("a", 10)) > 0`,
+			"function repeat is not allowed",
+		},
+		{
+			"does not reference enrollments",
+			"1 > 0",
+			"does not reference enrollments",
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			_, err := Compile(tt.withRule, BaseE{EnvType: TAdvanced})
+			if tt.wantErr == "" {
+				a.Nil(err)
+				return
+			}
+
+			a.ErrorContains(err, tt.wantErr)
+		})
+	}
+}
+
+func TestProgram_Run_memoryBudget(t *testing.T) {
+	a := assert.New(t)
+
+	env := BaseE{EnvType: TAdvanced}
+
+	prg, err := Compile("len(enrollments) > 0 || all(1..1000, {all(1..1000, {# > 0})})", env)
+	a.Nil(err)
+
+	_, err = prg.Run(env.SetFacts(nil))
+	a.ErrorContains(err, "memory budget exceeded")
+
+	var limitErr *LimitError
+	a.ErrorAs(err, &limitErr)
+	a.Equal(uint(1e6), vm.MemoryBudget)
+}
+
+func TestProgram_allocate(t *testing.T) {
+	a := assert.New(t)
+
+	prg, err := Compile("len(filter(enrollments, .Attended)) > 0", BaseE{EnvType: TAdvanced})
+	a.Nil(err)
+
+	prg.memory, prg.deadline = 0, time.Now().Add(ruleTimeout)
+	res, err := prg.allocate([]any{1, 2})
+	a.Nil(err)
+	a.Equal([]any{1, 2}, res)
+	a.Equal(2, prg.memory)
+
+	prg.memory = maxRuleMemory
+	_, err = prg.allocate([]any{1})
+	a.ErrorContains(err, "memory budget exceeded")
+
+	prg.memory, prg.deadline = 0, time.Now().Add(-time.Second)
+	_, err = prg.allocate([]any{1})
+	a.ErrorContains(err, "time limit")
+}
+
+func TestProgram_step(t *testing.T) {
+	a := assert.New(t)
+
+	prg, err := Compile("all(enrollments, {.Attended})", BaseE{EnvType: TAdvanced})
+	a.Nil(err)
+
+	prg.steps, prg.deadline = 0, time.Now().Add(ruleTimeout)
+	res, err := prg.step(true)
+	a.Nil(err)
+	a.Equal(true, res)
+
+	prg.steps = maxRuleSteps
+	_, err = prg.step(true)
+	a.ErrorContains(err, "steps")
+
+	prg.steps, prg.deadline = ruleTimeoutCheckInterval-1, time.Now().Add(-time.Second)
+	_, err = prg.step(true)
+	a.ErrorContains(err, "time limit")
+}
+
+func TestProgram_Run_steps(t *testing.T) {
+	a := assert.New(t)
+
+	env := BaseE{EnvType: TAdvanced}
+	facts := []Fact{{Attended: true}, {Attended: true}, {Attended: false}}
+
+	prg, err := Compile("count(enrollments, {.Attended}) == 2", env)
+	a.Nil(err)
+
+	for range 2 {
+		triggered, err := prg.Run(env.SetFacts(facts))
+		a.Nil(err)
+		a.True(triggered)
+		a.Equal(len(facts), prg.steps)
+	}
+}
@@ -3,8 +3,6 @@ package rules
 import (
 	_ "embed"
 	"errors"
-
-	"github.com/expr-lang/expr"
 )
 
 var (
@@ -13,15 +11,25 @@ var (
 
 	//go:embed rule_min_percentage_attendance_from_session.expr
 	percentageRule string
+
+	//go:embed rule_late_count.expr
+	lateCountRule string
 )
 
 type RuleParams struct {
-	Title             string            `json:"title"`
-	Description       string            `json:"description"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	RuleDefinition
+}
+
+// RuleDefinition contains the type and parameters of a rule, without the title and description of the rule.
+type RuleDefinition struct {
 	RuleType          T                 `json:"rule_type"`
 	ConsecutiveParams consecutiveParams `json:"consecutive_params"`
 	PercentageParams  percentageParams  `json:"percentage_params"`
 	AdvancedParams    advancedParams    `json:"advanced_params"`
+	LateCountParams   lateCountParams   `json:"late_count_params"`
+	FilterParams      filterParams      `json:"filter_params"`
 }
 
 type consecutiveParams struct {
@@ -37,13 +45,13 @@ type advancedParams struct {
 	Rule string `json:"rule"`
 }
 
-func (r RuleParams) Verify() (rule string, env E, err error) {
-	if len(r.Title) == 0 {
-		return "", nil, errors.New("title is empty")
-	}
+type lateCountParams struct {
+	LateCount int `json:"late_count"`
+}
 
-	if len(r.Description) == 0 {
-		return "", nil, errors.New("description is empty")
+func (r RuleParams) Verify() (rule string, env E, err error) {
+	if err = r.VerifyDetails(); err != nil {
+		return "", nil, err
 	}
 
 	switch r.RuleType {
@@ -53,24 +61,45 @@ func (r RuleParams) Verify() (rule string, env E, err error) {
 		return r.verifyPercentageRule()
 	case TAdvanced:
 		return r.verifyAdvancedRule()
+	case TLateCount:
+		return r.verifyLateCountRule()
 	default:
 		return "", nil, errors.New("unknown rule type")
 	}
 }
 
+// VerifyDetails verifies the title and description of a rule, without verifying its definition.
+func (r RuleParams) VerifyDetails() error {
+	if len(r.Title) == 0 {
+		return errors.New("title is empty")
+	}
+
+	if len(r.Description) == 0 {
+		return errors.New("description is empty")
+	}
+
+	return nil
+}
+
 func (r RuleParams) verifyConsecutiveRule() (rule string, env E, err error) {
 	if r.ConsecutiveParams.ConsecutiveClasses < 1 {
 		return "", nil, errors.New("number of consecutive classes cannot be less than 1")
 	}
 
+	filter, err := r.FilterParams.verify()
+	if err != nil {
+		return "", nil, err
+	}
+
 	env = ConsecutiveE{
 		BaseE: BaseE{
 			EnvType: TConsecutive,
 		},
+		FilterE:            filter,
 		ConsecutiveClasses: r.ConsecutiveParams.ConsecutiveClasses,
 	}
 
-	_, err = expr.Compile(consecutiveRule, expr.AsBool(), expr.Env(env))
+	_, err = Compile(consecutiveRule, env)
 	return consecutiveRule, env, err
 }
 
@@ -87,23 +116,55 @@ func (r RuleParams) verifyPercentageRule() (rule string, env E, err error) {
 		return "", nil, errors.New("number of sessions cannot be less than 1")
 	}
 
+	filter, err := r.FilterParams.verify()
+	if err != nil {
+		return "", nil, err
+	}
+
 	env = PercentageE{
 		BaseE: BaseE{
 			EnvType: TPercentage,
 		},
+		FilterE:     filter,
 		Percentage:  params.Percentage,
 		FromSession: params.FromSession,
 	}
 
-	_, err = expr.Compile(percentageRule, expr.AsBool(), expr.Env(env))
+	_, err = Compile(percentageRule, env)
 	return percentageRule, env, err
 }
 
 func (r RuleParams) verifyAdvancedRule() (rule string, env E, err error) {
+	if !r.FilterParams.isEmpty() {
+		return "", nil, errors.New("filters are not supported for advanced rules")
+	}
+
 	env = BaseE{
 		EnvType: TAdvanced,
 	}
 
-	_, err = expr.Compile(r.AdvancedParams.Rule, expr.AsBool(), expr.Env(env))
+	_, err = Compile(r.AdvancedParams.Rule, env)
 	return r.AdvancedParams.Rule, env, err
 }
+
+func (r RuleParams) verifyLateCountRule() (rule string, env E, err error) {
+	if r.LateCountParams.LateCount < 1 {
+		return "", nil, errors.New("number of late classes cannot be less than 1")
+	}
+
+	filter, err := r.FilterParams.verify()
+	if err != nil {
+		return "", nil, err
+	}
+
+	env = LateCountE{
+		BaseE: BaseE{
+			EnvType: TLateCount,
+		},
+		FilterE:   filter,
+		LateCount: r.LateCountParams.LateCount,
+	}
+
+	_, err = Compile(lateCountRule, env)
+	return lateCountRule, env, err
+}
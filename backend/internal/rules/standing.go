@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"fmt"
+)
+
+const (
+	statusAbsent  = "ABSENT"
+	statusExcused = "EXCUSED"
+)
+
+// ExcludeExcused removes the facts of sessions that a student has been excused from, so that approved absences do not
+// count towards any rule.
+func ExcludeExcused(facts []Fact) []Fact {
+	res := make([]Fact, 0, len(facts))
+	for _, fact := range facts {
+		if fact.Status != statusExcused {
+			res = append(res, fact)
+		}
+	}
+
+	return res
+}
+
+// Standing describes how close a student is to triggering a rule.
+//
+// RemainingAbsences is the number of upcoming sessions that the student can miss in a row before the rule triggers. It
+// is nil if the rule does not trigger even if all upcoming sessions are missed.
+type Standing struct {
+	Triggered         bool `json:"triggered"`
+	RemainingAbsences *int `json:"remaining_absences"`
+}
+
+// EvaluateStanding evaluates a rule against the facts of past sessions to check if it has triggered. If it has not,
+// the rule is evaluated again with each upcoming session counted as missed in turn, to find how many more absences
+// are allowed. Excused sessions should already be excluded from the facts.
+func EvaluateStanding(rule string, env E, past, upcoming []Fact) (Standing, error) {
+	var standing Standing
+
+	prg, err := Compile(rule, env)
+	if err != nil {
+		return standing, fmt.Errorf("could not compile rule: %w", err)
+	}
+
+	facts := append(make([]Fact, 0, len(past)+len(upcoming)), past...)
+	for absences := 0; absences <= len(upcoming); absences++ {
+		if absences > 0 {
+			missed := upcoming[absences-1]
+			missed.Status, missed.Attended = statusAbsent, false
+			facts = append(facts, missed)
+		}
+
+		triggered, err := prg.Run(env.SetFacts(facts))
+		switch {
+		case err != nil:
+			return standing, fmt.Errorf("could not run rule: %w", err)
+		case !triggered:
+			continue
+		case absences == 0:
+			standing.Triggered = true
+		}
+
+		remaining := max(absences-1, 0)
+		standing.RemainingAbsences = &remaining
+		return standing, nil
+	}
+
+	return standing, nil
+}
@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateStanding(t *testing.T) {
+	attended := Fact{Status: "PRESENT", Attended: true}
+	missed := Fact{Status: "ABSENT", Attended: false}
+
+	consecutive := func(n int) E {
+		return ConsecutiveE{BaseE: BaseE{EnvType: TConsecutive}, ConsecutiveClasses: n}
+	}
+
+	tts := []struct {
+		name          string
+		withEnv       E
+		withPast      []Fact
+		withUpcoming  []Fact
+		wantTriggered bool
+		wantRemaining *int
+	}{
+		{
+			"rule already triggered",
+			consecutive(2),
+			[]Fact{attended, missed, missed},
+			[]Fact{missed},
+			true,
+			to.Ptr(0),
+		},
+		{
+			"rule triggers on next absence",
+			consecutive(2),
+			[]Fact{attended, missed},
+			[]Fact{missed, missed},
+			false,
+			to.Ptr(0),
+		},
+		{
+			"rule allows more absences",
+			consecutive(3),
+			[]Fact{attended},
+			[]Fact{missed, missed, missed},
+			false,
+			to.Ptr(2),
+		},
+		{
+			"rule does not trigger within upcoming sessions",
+			consecutive(3),
+			[]Fact{attended},
+			[]Fact{missed},
+			false,
+			nil,
+		},
+		{
+			"upcoming sessions are counted as missed",
+			consecutive(2),
+			[]Fact{attended},
+			[]Fact{attended, attended},
+			false,
+			to.Ptr(1),
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			standing, err := EvaluateStanding(consecutiveRule, tt.withEnv, tt.withPast, tt.withUpcoming)
+			a.Nil(err)
+			a.Equal(tt.wantTriggered, standing.Triggered)
+			a.Equal(tt.wantRemaining, standing.RemainingAbsences)
+		})
+	}
+}
+
+func TestExcludeExcused(t *testing.T) {
+	a := assert.New(t)
+
+	facts := []Fact{
+		{Status: "PRESENT", Attended: true},
+		{Status: "EXCUSED"},
+		{Status: "ABSENT"},
+	}
+
+	a.Equal([]Fact{facts[0], facts[2]}, ExcludeExcused(facts))
+}
@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	// Schedules are in local time, which must be available in containers without time zone data.
+	_ "time/tzdata"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"go.uber.org/zap"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/env"
+	"github.com/darylhjd/oams/backend/internal/intervention"
+	"github.com/darylhjd/oams/backend/internal/logger"
+	"github.com/darylhjd/oams/backend/pkg/cron"
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+)
+
+const (
+	Namespace = "scheduler"
+)
+
+const (
+	// pollInterval is how often the scheduler checks for jobs that are due.
+	pollInterval = time.Second * 30
+
+	// maxCatchUpRuns is the maximum number of runs of a job that are caught up on at once. Only the latest missed runs
+	// are run.
+	maxCatchUpRuns = 24
+
+	// interventionJobName is the name of the job that runs the intervention service.
+	interventionJobName = "intervention"
+)
+
+// Job is a task that is run on a cron schedule. Run is given the scheduled time of the run, which is earlier than the
+// current time when catching up on a missed run. The advisory lock with LockKey is held while the job runs.
+type Job struct {
+	Name     string
+	Schedule *cron.Schedule
+	LockKey  int64
+	Run      func(ctx context.Context, at time.Time) error
+}
+
+// Scheduler runs jobs on their schedules. Jobs are guarded by Postgres advisory locks so that each scheduled run is
+// executed by only one scheduler when there are many replicas. The latest scheduled time of each job that was run is
+// stored in the database, so that runs missed while no scheduler was running are caught up on, up to the catch-up
+// window.
+type Scheduler struct {
+	l  *zap.Logger
+	db *database.DB
+
+	jobs          []Job
+	catchUpWindow time.Duration
+
+	interventionService *intervention.Service
+}
+
+// New creates the scheduler with the jobs configured through the environment. Use Start to start running jobs.
+func New(ctx context.Context) (*Scheduler, error) {
+	l, err := logger.NewLogger()
+	if err != nil {
+		return nil, fmt.Errorf("%s - failed to initialise: %w", Namespace, err)
+	}
+
+	schedule, err := cron.Parse(env.GetSchedulerInterventionCron())
+	if err != nil {
+		return nil, fmt.Errorf("%s - could not parse intervention schedule: %w", Namespace, err)
+	}
+
+	db, err := database.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s - could not connect to database: %w", Namespace, err)
+	}
+
+	interventionService, err := intervention.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s - could not create intervention service: %w", Namespace, err)
+	}
+
+	return &Scheduler{
+		l, db,
+		[]Job{{interventionJobName, schedule, intervention.RunLockKey, interventionService.RunAt}},
+		env.GetSchedulerCatchUpWindow(),
+		interventionService,
+	}, nil
+}
+
+// Start runs jobs as they become due. This function blocks until the context is cancelled.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.l.Info(fmt.Sprintf("%s - starting scheduler...", Namespace), zap.Int("num_jobs", len(s.jobs)))
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, job := range s.jobs {
+			if err := s.runDue(ctx, job, time.Now()); err != nil && ctx.Err() == nil {
+				s.l.Error(fmt.Sprintf("%s - could not run job", Namespace), zap.String("job", job.Name), zap.Error(err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			s.l.Info(fmt.Sprintf("%s - scheduler stopped", Namespace))
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDue runs a job for each of its scheduled times that have not been run. Nothing is done if another scheduler holds
+// the lock of the job.
+func (s *Scheduler) runDue(ctx context.Context, job Job, now time.Time) error {
+	lastScheduledAt, err := s.lastScheduledAt(ctx, job, now)
+	if err != nil {
+		return err
+	}
+
+	if len(dueTimes(job.Schedule, lastScheduledAt, now)) == 0 {
+		return nil
+	}
+
+	lock, ok, err := s.db.TryAdvisoryLock(ctx, job.LockKey)
+	if err != nil || !ok {
+		return err
+	}
+	defer func() {
+		if err := lock.Release(context.Background()); err != nil {
+			s.l.Warn(fmt.Sprintf("%s - could not release job lock", Namespace), zap.String("job", job.Name), zap.Error(err))
+		}
+	}()
+
+	// Another scheduler may have run the job before the lock was acquired.
+	if lastScheduledAt, err = s.lastScheduledAt(ctx, job, now); err != nil {
+		return err
+	}
+
+	for _, at := range dueTimes(job.Schedule, lastScheduledAt, now) {
+		s.l.Info(fmt.Sprintf("%s - running job", Namespace), zap.String("job", job.Name), zap.Time("scheduled_at", at))
+
+		// A failed run is not retried, so that a persistent failure does not block later runs.
+		if err = job.Run(ctx, at); err != nil {
+			s.l.Error(
+				fmt.Sprintf("%s - job failed", Namespace),
+				zap.String("job", job.Name),
+				zap.Time("scheduled_at", at),
+				zap.Error(err),
+			)
+		}
+
+		if _, err = s.db.UpsertScheduledJob(ctx, job.Name, at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lastScheduledAt returns the latest scheduled time that a job was run for. Runs earlier than the catch-up window are
+// not caught up on. When a job is first started, it is recorded as scheduled now, so that no runs are caught up on.
+func (s *Scheduler) lastScheduledAt(ctx context.Context, job Job, now time.Time) (time.Time, error) {
+	earliest := now.Add(-s.catchUpWindow)
+
+	scheduledJob, err := s.db.GetScheduledJob(ctx, job.Name)
+	if errors.Is(err, qrm.ErrNoRows) {
+		scheduledJob, err = s.db.CreateScheduledJob(ctx, job.Name, now)
+	}
+
+	switch {
+	case err != nil:
+		return time.Time{}, err
+	case scheduledJob.LastScheduledAt.Before(earliest):
+		return earliest, nil
+	default:
+		return scheduledJob.LastScheduledAt, nil
+	}
+}
+
+// dueTimes returns the scheduled times after the last scheduled time, up to now. At most maxCatchUpRuns of the latest
+// times are returned. Schedules are in local time.
+func dueTimes(schedule *cron.Schedule, lastScheduledAt, now time.Time) []time.Time {
+	var times []time.Time
+	for at := schedule.Next(lastScheduledAt.In(datetime.Location)); !at.IsZero() && !at.After(now); at = schedule.Next(at) {
+		times = append(times, at)
+	}
+
+	return times[max(len(times)-maxCatchUpRuns, 0):]
+}
+
+// Stop closes the connections of the scheduler. Start should have returned before this is called.
+func (s *Scheduler) Stop() error {
+	return errors.Join(s.interventionService.Stop(), s.db.Close())
+}
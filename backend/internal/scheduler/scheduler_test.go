@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/darylhjd/oams/backend/pkg/cron"
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+)
+
+func TestDueTimes(t *testing.T) {
+	daily, err := cron.Parse("0 18 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	everyMinute, err := cron.Parse("* * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, datetime.Location)
+	}
+
+	tts := []struct {
+		name                string
+		withSchedule        *cron.Schedule
+		withLastScheduledAt time.Time
+		withNow             time.Time
+		wantTimes           []time.Time
+	}{
+		{
+			"not due",
+			daily,
+			at(15, 18, 0),
+			at(16, 17, 59),
+			nil,
+		},
+		{
+			"due now",
+			daily,
+			at(15, 18, 0),
+			at(16, 18, 0),
+			[]time.Time{at(16, 18, 0)},
+		},
+		{
+			"missed runs",
+			daily,
+			at(15, 18, 0),
+			at(18, 9, 0),
+			[]time.Time{at(16, 18, 0), at(17, 18, 0)},
+		},
+		{
+			"last scheduled time in another location",
+			daily,
+			at(15, 18, 0).UTC(),
+			at(16, 18, 30),
+			[]time.Time{at(16, 18, 0)},
+		},
+		{
+			"catch up limited to latest runs",
+			everyMinute,
+			at(15, 0, 0),
+			at(15, 1, 0),
+			func() []time.Time {
+				var times []time.Time
+				for minute := 60 - maxCatchUpRuns + 1; minute <= 60; minute++ {
+					times = append(times, at(15, 0, minute))
+				}
+				return times
+			}(),
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			times := dueTimes(tt.withSchedule, tt.withLastScheduledAt, tt.withNow)
+			a.Len(times, len(tt.wantTimes))
+			for idx := range tt.wantTimes {
+				a.True(tt.wantTimes[idx].Equal(times[idx]), "got %s, want %s", times[idx], tt.wantTimes[idx])
+			}
+		})
+	}
+}
@@ -91,9 +91,14 @@ func generateClassesCSV(r *http.Request, txDb *database.DB, archive *zip.Writer)
 	writer := csv.NewWriter(file)
 	records := append(
 		make([][]string, 0, len(classes)+1),
-		[]string{"id", "code", "year", "semester", "programme", "au", "created_at", "updated_at"},
+		[]string{"id", "code", "year", "semester", "programme", "au", "late_threshold_minutes", "attendance_locked_after", "attendance_opens_before_minutes", "attendance_closes_after_minutes", "created_at", "updated_at"},
 	)
 	for _, class := range classes {
+		var attendanceLockedAfter string
+		if class.AttendanceLockedAfter != nil {
+			attendanceLockedAfter = class.AttendanceLockedAfter.String()
+		}
+
 		records = append(records, []string{
 			strconv.FormatInt(class.ID, 10),
 			class.Code,
@@ -101,6 +106,10 @@ func generateClassesCSV(r *http.Request, txDb *database.DB, archive *zip.Writer)
 			class.Semester,
 			class.Programme,
 			strconv.FormatInt(int64(class.Au), 10),
+			strconv.FormatInt(int64(class.LateThresholdMinutes), 10),
+			attendanceLockedAfter,
+			strconv.FormatInt(int64(class.AttendanceOpensBeforeMinutes), 10),
+			strconv.FormatInt(int64(class.AttendanceClosesAfterMinutes), 10),
 			class.CreatedAt.String(),
 			class.UpdatedAt.String(),
 		})
@@ -161,14 +170,24 @@ func generateClassGroupsCSV(r *http.Request, txDb *database.DB, archive *zip.Wri
 	writer := csv.NewWriter(file)
 	records := append(
 		make([][]string, 0, len(groups)+1),
-		[]string{"id", "class_id", "name", "class_type", "created_at", "updated_at"},
+		[]string{"id", "class_id", "name", "class_type", "attendance_opens_before_minutes", "attendance_closes_after_minutes", "created_at", "updated_at"},
 	)
 	for _, group := range groups {
+		var attendanceOpensBeforeMinutes, attendanceClosesAfterMinutes string
+		if group.AttendanceOpensBeforeMinutes != nil {
+			attendanceOpensBeforeMinutes = strconv.FormatInt(int64(*group.AttendanceOpensBeforeMinutes), 10)
+		}
+		if group.AttendanceClosesAfterMinutes != nil {
+			attendanceClosesAfterMinutes = strconv.FormatInt(int64(*group.AttendanceClosesAfterMinutes), 10)
+		}
+
 		records = append(records, []string{
 			strconv.FormatInt(group.ID, 10),
 			strconv.FormatInt(group.ClassID, 10),
 			group.Name,
 			string(group.ClassType),
+			attendanceOpensBeforeMinutes,
+			attendanceClosesAfterMinutes,
 			group.CreatedAt.String(),
 			group.UpdatedAt.String(),
 		})
@@ -252,14 +271,26 @@ func generateSessionEnrollmentsCSV(r *http.Request, txDb *database.DB, archive *
 	writer := csv.NewWriter(file)
 	records := append(
 		make([][]string, 0, len(enrollments)+1),
-		[]string{"id", "session_id", "user_id", "attended", "created_at", "updated_at"},
+		[]string{"id", "session_id", "user_id", "status", "marked_by", "checked_in_at", "source", "created_at", "updated_at"},
 	)
 	for _, enrollment := range enrollments {
+		var markedBy, checkedInAt string
+		if enrollment.MarkedBy != nil {
+			markedBy = *enrollment.MarkedBy
+		}
+
+		if enrollment.CheckedInAt != nil {
+			checkedInAt = enrollment.CheckedInAt.String()
+		}
+
 		records = append(records, []string{
 			strconv.FormatInt(enrollment.ID, 10),
 			strconv.FormatInt(enrollment.SessionID, 10),
 			enrollment.UserID,
-			strconv.FormatBool(enrollment.Attended),
+			string(enrollment.Status),
+			markedBy,
+			checkedInAt,
+			string(enrollment.Source),
 			enrollment.CreatedAt.String(),
 			enrollment.UpdatedAt.String(),
 		})
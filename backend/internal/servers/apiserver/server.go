@@ -10,11 +10,14 @@ import (
 	"github.com/rs/cors"
 	"go.uber.org/zap"
 
+	"github.com/darylhjd/oams/backend/internal/checkin"
 	"github.com/darylhjd/oams/backend/internal/database"
 	"github.com/darylhjd/oams/backend/internal/env"
 	"github.com/darylhjd/oams/backend/internal/logger"
 	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/scheduler"
 	"github.com/darylhjd/oams/backend/internal/servers/apiserver/v1"
+	"github.com/darylhjd/oams/backend/internal/stream"
 )
 
 const (
@@ -28,6 +31,14 @@ type APIServer struct {
 	db  *database.DB
 	mux *http.ServeMux
 
+	broker     *stream.Broker
+	stopBroker context.CancelFunc
+
+	// scheduler runs the intervention service on schedule if it is configured. It is nil otherwise. stopScheduler waits
+	// for the scheduler to stop.
+	scheduler     *scheduler.Scheduler
+	stopScheduler func()
+
 	v1 *v1.APIServerV1
 }
 
@@ -48,7 +59,22 @@ func New(ctx context.Context) (*APIServer, error) {
 		return nil, fmt.Errorf("%s - could not create azure authenticator: %w", Namespace, err)
 	}
 
-	server := &APIServer{l, db, http.NewServeMux(), v1.New(l, db, azureAuthenticator)}
+	checkInIssuer := checkin.NewIssuer([]byte(env.GetAPIServerCheckInSecret()))
+	broker := stream.NewBroker(l)
+
+	var s *scheduler.Scheduler
+	if env.GetSchedulerInterventionCron() != "" {
+		if s, err = scheduler.New(ctx); err != nil {
+			return nil, fmt.Errorf("%s - could not create scheduler: %w", Namespace, err)
+		}
+	}
+
+	server := &APIServer{
+		l, db, http.NewServeMux(),
+		broker, func() {},
+		s, func() {},
+		v1.New(l, db, azureAuthenticator, checkInIssuer, broker),
+	}
 	server.registerHandlers()
 
 	return server, nil
@@ -67,6 +93,26 @@ func (s *APIServer) Start() error {
 		ExposedHeaders:   []string{"Content-Disposition"},
 	})
 
+	// Relay attendance changes from the database to attendance streams.
+	var brokerCtx context.Context
+	brokerCtx, s.stopBroker = context.WithCancel(context.Background())
+	go s.broker.Listen(brokerCtx, s.db)
+
+	// Run the intervention service on schedule alongside the API.
+	if s.scheduler != nil {
+		schedulerCtx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		s.stopScheduler = func() {
+			cancel()
+			<-done
+		}
+
+		go func() {
+			defer close(done)
+			_ = s.scheduler.Start(schedulerCtx)
+		}()
+	}
+
 	port := env.GetAPIServerPort()
 	s.l.Info(fmt.Sprintf("%s - service started", Namespace), zap.String("port", port))
 	return http.ListenAndServe(fmt.Sprintf(":%s", port), c.Handler(s))
@@ -106,5 +152,14 @@ func (s *APIServer) base(w http.ResponseWriter, _ *http.Request) {
 
 // Stop closes any external connections (e.g. database) and stops the server gracefully.
 func (s *APIServer) Stop() error {
+	s.stopBroker()
+	s.stopScheduler()
+
+	if s.scheduler != nil {
+		if err := s.scheduler.Stop(); err != nil {
+			return err
+		}
+	}
+
 	return s.db.Close()
 }
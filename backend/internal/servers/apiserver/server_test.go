@@ -49,6 +49,6 @@ func newTestAPIServer(t *testing.T) *APIServer {
 	return &APIServer{
 		l:   zap.NewNop(),
 		mux: http.NewServeMux(),
-		v1:  v1.New(zap.NewNop(), nil, nil),
+		v1:  v1.New(zap.NewNop(), nil, nil, nil, nil),
 	}
 }
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) attendanceAmendments(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.attendanceAmendmentsGet(r)
+	case http.MethodPost:
+		resp = v.attendanceAmendmentsPost(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type attendanceAmendmentsGetResponse struct {
+	response
+	AttendanceAmendments []database.AttendanceAmendment `json:"attendance_amendments"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) attendanceAmendmentsGet(r *http.Request) apiResponse {
+	amendments, err := v.db.GetAttendanceAmendments(r.Context())
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process attendance amendments get database action")
+	}
+
+	return attendanceAmendmentsGetResponse{
+		newSuccessResponse(),
+		append(make([]database.AttendanceAmendment, 0, len(amendments)), amendments...),
+	}
+}
+
+type attendanceAmendmentsPostRequest struct {
+	database.CreateAttendanceAmendmentParams
+}
+
+type attendanceAmendmentsPostResponse struct {
+	response
+	AttendanceAmendment database.AttendanceAmendment `json:"attendance_amendment"`
+}
+
+// attendanceAmendmentsPost proposes a change to the attendance of a session that has ended. The change only takes effect
+// once it is approved by a course coordinator of the class.
+func (v *APIServerV1) attendanceAmendmentsPost(r *http.Request) apiResponse {
+	var req attendanceAmendmentsPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	if !isValidAttendanceStatus(req.ProposedStatus) {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("unknown attendance status %q", req.ProposedStatus))
+	}
+
+	if req.Reason = strings.TrimSpace(req.Reason); req.Reason == "" {
+		return newErrorResponse(http.StatusBadRequest, "a reason is required")
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	amendment, err := txDb.CreateAttendanceAmendment(r.Context(), req.CreateAttendanceAmendmentParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, qrm.ErrNoRows):
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to amend attendance")
+		case database.ErrSQLState(err, database.SQLStateDuplicateKeyOrIndex):
+			return newErrorResponse(http.StatusConflict, "an amendment is already pending for this session enrollment")
+		default:
+			v.logInternalServerError(r, err)
+			return newErrorResponse(http.StatusInternalServerError, "could not process attendance amendments post database action")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
+	return attendanceAmendmentsPostResponse{
+		newSuccessResponse(),
+		amendment,
+	}
+}
@@ -0,0 +1,212 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/tests"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIServerV1_attendanceAmendmentsPost(t *testing.T) {
+	t.Parallel()
+
+	tts := []struct {
+		name                  string
+		withSessionEnded      bool
+		withLockedTime        *time.Time
+		withExistingAmendment bool
+		withProposedStatus    model.AttendanceStatus
+		withReason            string
+		wantStatusCode        int
+		wantErr               string
+	}{
+		{
+			"request for closed session",
+			true,
+			nil,
+			false,
+			model.AttendanceStatus_Present,
+			"student was present",
+			http.StatusOK,
+			"",
+		},
+		{
+			"request for closed session with future lock",
+			true,
+			to.Ptr(time.Now().Add(time.Hour)),
+			false,
+			model.AttendanceStatus_Present,
+			"student was present",
+			http.StatusOK,
+			"",
+		},
+		{
+			"request for open session",
+			false,
+			nil,
+			false,
+			model.AttendanceStatus_Present,
+			"student was present",
+			http.StatusUnauthorized,
+			"not allowed to amend attendance",
+		},
+		{
+			"request for class with locked attendance",
+			true,
+			to.Ptr(time.Now().Add(-time.Hour)),
+			false,
+			model.AttendanceStatus_Present,
+			"student was present",
+			http.StatusUnauthorized,
+			"not allowed to amend attendance",
+		},
+		{
+			"request with pending amendment",
+			true,
+			nil,
+			true,
+			model.AttendanceStatus_Present,
+			"student was present",
+			http.StatusConflict,
+			"an amendment is already pending",
+		},
+		{
+			"request with unknown status",
+			true,
+			nil,
+			false,
+			"UNKNOWN",
+			"student was present",
+			http.StatusBadRequest,
+			"unknown attendance status",
+		},
+		{
+			"request without reason",
+			true,
+			nil,
+			false,
+			model.AttendanceStatus_Present,
+			" ",
+			http.StatusBadRequest,
+			"a reason is required",
+		},
+	}
+
+	for _, tt := range tts {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := assert.New(t)
+			ctx := context.WithValue(context.Background(), oauth2.AuthContextKey, tests.StubAuthContext())
+			id := uuid.NewString()
+
+			v1 := newTestAPIServerV1(t, id)
+			defer tests.TearDown(t, v1.db, id)
+
+			tests.StubUser(t, ctx, v1.db, database.CreateUserParams{
+				ID:   tests.MockAuthenticatorUserID,
+				Role: tests.MockAuthenticatorUserRole,
+			})
+
+			startTime, endTime := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+			if tt.withSessionEnded {
+				startTime, endTime = time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, -1).Add(time.Hour)
+			}
+
+			enrollment := tests.StubSessionEnrollmentAt(t, ctx, v1.db, startTime, endTime, model.AttendanceStatus_Absent)
+			if tt.withLockedTime != nil {
+				lockClassAttendance(t, ctx, v1.db, enrollment, *tt.withLockedTime)
+			}
+
+			if tt.withExistingAmendment {
+				if _, err := v1.db.CreateAttendanceAmendment(ctx, database.CreateAttendanceAmendmentParams{
+					SessionEnrollmentID: enrollment.ID,
+					ProposedStatus:      model.AttendanceStatus_Late,
+					Reason:              "student was late",
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			body, err := json.Marshal(database.CreateAttendanceAmendmentParams{
+				SessionEnrollmentID: enrollment.ID,
+				ProposedStatus:      tt.withProposedStatus,
+				Reason:              tt.withReason,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httpRequestWithAuthContext(
+				httptest.NewRequest(http.MethodPost, attendanceAmendmentsUrl, bytes.NewReader(body)),
+				tests.StubAuthContext(),
+			)
+			resp := v1.attendanceAmendmentsPost(req)
+			a.Equal(tt.wantStatusCode, resp.Code())
+
+			switch {
+			case tt.wantErr != "":
+				actualResp, ok := resp.(errorResponse)
+				a.True(ok)
+				a.Contains(actualResp.Error, tt.wantErr)
+			default:
+				actualResp, ok := resp.(attendanceAmendmentsPostResponse)
+				a.True(ok)
+				a.Equal(enrollment.ID, actualResp.AttendanceAmendment.SessionEnrollmentID)
+				a.Equal(tests.MockAuthenticatorUserID, actualResp.AttendanceAmendment.RequesterID)
+				a.Equal(model.AttendanceStatus_Absent, actualResp.AttendanceAmendment.PreviousStatus)
+				a.Equal(tt.withProposedStatus, actualResp.AttendanceAmendment.ProposedStatus)
+				a.Equal(model.AmendmentStatus_Pending, actualResp.AttendanceAmendment.Status)
+			}
+
+			// The attendance is only changed once the amendment is approved.
+			actualEnrollment, err := v1.db.GetSessionEnrollment(ctx, enrollment.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a.Equal(model.AttendanceStatus_Absent, actualEnrollment.Status)
+		})
+	}
+}
+
+// enrollmentClassId returns the ID of the class of a session enrollment.
+func enrollmentClassId(t *testing.T, ctx context.Context, db *database.DB, enrollment model.SessionEnrollment) int64 {
+	t.Helper()
+
+	session, err := db.GetClassGroupSession(ctx, enrollment.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := db.GetClassGroup(ctx, session.ClassGroupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return group.ClassID
+}
+
+// lockClassAttendance sets the attendance lock of the class of a session enrollment.
+func lockClassAttendance(t *testing.T, ctx context.Context, db *database.DB, enrollment model.SessionEnrollment, lockedAfter time.Time) {
+	t.Helper()
+
+	if _, err := db.UpdateCoordinatingClass(ctx, database.UpdateCoordinatingClassParams{
+		ID:                    enrollmentClassId(t, ctx, db, enrollment),
+		AttendanceLockedAfter: database.Nullable[time.Time]{Set: true, Value: &lockedAfter},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 	"github.com/darylhjd/oams/backend/internal/servers/apiserver/common"
 	"github.com/darylhjd/oams/backend/pkg/goroutines"
 	"github.com/darylhjd/oams/backend/pkg/to"
@@ -260,6 +261,7 @@ func (v *APIServerV1) processBatchPutRequest(r *http.Request, req batchPutReques
 			enrollmentsParams = append(enrollmentsParams, database.UpsertSessionEnrollmentParams{
 				SessionID: session.ID,
 				UserID:    users[i][idx],
+				Status:    model.AttendanceStatus_Absent,
 			})
 		}
 	}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+)
+
+const (
+	maxCardTapsPerRequest = 1000
+)
+
+func (v *APIServerV1) cardTaps(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodPost:
+		resp = v.cardTapsPost(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type cardTapsPostRequest struct {
+	Taps []database.RecordCardTapParams `json:"taps"`
+}
+
+type cardTapsPostResponse struct {
+	response
+	Results []database.CardTapResult `json:"results"`
+}
+
+// cardTapsPost records attendance from a batch of card reader taps. The outcome of each tap is reported in the same
+// order as the taps in the request.
+func (v *APIServerV1) cardTapsPost(r *http.Request) apiResponse {
+	var req cardTapsPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	if len(req.Taps) > maxCardTapsPerRequest {
+		return newErrorResponse(http.StatusRequestEntityTooLarge, fmt.Sprintf("at most %d taps can be sent at once", maxCardTapsPerRequest))
+	}
+
+	for idx := range req.Taps {
+		req.Taps[idx].CardUID = normalizeCardUid(req.Taps[idx].CardUID)
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	results, err := txDb.RecordCardTaps(r.Context(), req.Taps)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process card taps post database action")
+	}
+
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
+	return cardTapsPostResponse{
+		newSuccessResponse(),
+		append(make([]database.CardTapResult, 0, len(results)), results...),
+	}
+}
@@ -0,0 +1,201 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/tests"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIServerV1_cardTaps(t *testing.T) {
+	t.Parallel()
+
+	tts := []struct {
+		name           string
+		withMethod     string
+		wantStatusCode int
+	}{
+		{
+			"with POST method",
+			http.MethodPost,
+			http.StatusBadRequest,
+		},
+		{
+			"with GET method",
+			http.MethodGet,
+			http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tts {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := assert.New(t)
+			id := uuid.NewString()
+
+			v1 := newTestAPIServerV1(t, id)
+			defer tests.TearDown(t, v1.db, id)
+
+			req := httpRequestWithAuthContext(
+				httptest.NewRequest(tt.withMethod, cardTapsUrl, nil),
+				tests.StubAuthContext(),
+			)
+			rr := httptest.NewRecorder()
+			v1.cardTaps(rr, req)
+
+			a.Equal(tt.wantStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestAPIServerV1_cardTapsPost(t *testing.T) {
+	t.Parallel()
+
+	tts := []struct {
+		name           string
+		withKnownCard  bool
+		withTapAge     time.Duration
+		withLockedTime *time.Time
+		wantOutcome    database.CardTapOutcome
+		wantStatus     *model.AttendanceStatus
+	}{
+		{
+			"tap during open session",
+			true,
+			0,
+			nil,
+			database.CardTapOutcomeRecorded,
+			to.Ptr(model.AttendanceStatus_Present),
+		},
+		{
+			"tap with unknown card",
+			false,
+			0,
+			nil,
+			database.CardTapOutcomeUnknownCard,
+			nil,
+		},
+		{
+			"tap older than maximum age",
+			true,
+			2 * time.Hour,
+			nil,
+			database.CardTapOutcomeOutOfWindow,
+			nil,
+		},
+		{
+			"tap for class with locked attendance",
+			true,
+			0,
+			to.Ptr(time.Now().Add(-time.Minute)),
+			database.CardTapOutcomeOutOfWindow,
+			nil,
+		},
+	}
+
+	for _, tt := range tts {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := assert.New(t)
+			ctx := context.WithValue(context.Background(), oauth2.AuthContextKey, tests.StubAuthContext())
+			id := uuid.NewString()
+
+			v1 := newTestAPIServerV1(t, id)
+			defer tests.TearDown(t, v1.db, id)
+
+			tests.StubUser(t, ctx, v1.db, database.CreateUserParams{
+				ID:   tests.MockAuthenticatorUserID,
+				Role: tests.MockAuthenticatorUserRole,
+			})
+
+			now := time.Now()
+			venue := uuid.NewString()
+			tapTime := now.Add(-tt.withTapAge)
+			session := tests.StubClassGroupSession(t, ctx, v1.db, tapTime.Add(-time.Minute), now.Add(time.Hour), venue)
+			user := tests.StubUser(t, ctx, v1.db, database.CreateUserParams{
+				ID:   uuid.NewString(),
+				Role: model.UserRole_User,
+			})
+			enrollment, err := v1.db.CreateSessionEnrollment(ctx, database.CreateSessionEnrollmentParams{
+				SessionID: session.ID,
+				UserID:    user.ID,
+				Status:    model.AttendanceStatus_Absent,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.withKnownCard {
+				if _, err = v1.db.CreateUserCard(ctx, database.CreateUserCardParams{
+					CardUID: "AB12CD34",
+					UserID:  user.ID,
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.withLockedTime != nil {
+				group, err := v1.db.GetClassGroup(ctx, session.ClassGroupID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err = v1.db.UpdateCoordinatingClass(ctx, database.UpdateCoordinatingClassParams{
+					ID:                    group.ClassID,
+					AttendanceLockedAfter: database.Nullable[time.Time]{Set: true, Value: tt.withLockedTime},
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			body, err := json.Marshal(cardTapsPostRequest{
+				[]database.RecordCardTapParams{
+					{
+						ReaderID:  uuid.NewString(),
+						Venue:     venue,
+						CardUID:   " ab12cd34 ",
+						Timestamp: tapTime,
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httpRequestWithAuthContext(
+				httptest.NewRequest(http.MethodPost, cardTapsUrl, bytes.NewReader(body)),
+				tests.StubAuthContext(),
+			)
+			resp := v1.cardTapsPost(req)
+			a.Equal(http.StatusOK, resp.Code())
+
+			actualResp, ok := resp.(cardTapsPostResponse)
+			a.True(ok)
+			a.Len(actualResp.Results, 1)
+
+			result := actualResp.Results[0]
+			a.Equal(tt.wantOutcome, result.Outcome)
+			a.Equal(tt.wantStatus, result.Status)
+			if tt.wantOutcome == database.CardTapOutcomeRecorded {
+				a.Equal(&enrollment.ID, result.SessionEnrollmentID)
+			} else {
+				a.Nil(result.SessionEnrollmentID)
+			}
+		})
+	}
+}
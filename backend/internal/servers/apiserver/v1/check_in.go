@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) checkIn(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodPost:
+		resp = v.checkInPost(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type checkInPostRequest struct {
+	Token string `json:"token"`
+}
+
+type checkInPostResponse struct {
+	response
+	SessionEnrollment model.SessionEnrollment `json:"session_enrollment"`
+}
+
+// checkInPost allows a student to mark their own attendance using a check-in token for a class group session.
+func (v *APIServerV1) checkInPost(r *http.Request) apiResponse {
+	var req checkInPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	sessionId, err := v.checkInIssuer.Verify(req.Token, time.Now())
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, "check-in token is invalid or has expired")
+	}
+
+	enrollment, err := v.db.CheckInAttendanceEntry(r.Context(), sessionId)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "no enrollment for this session is open for check-in")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not check in")
+	}
+
+	return checkInPostResponse{
+		newSuccessResponse(),
+		enrollment,
+	}
+}
@@ -70,6 +70,9 @@ func TestAPIServerV1_classGet(t *testing.T) {
 				})
 
 				tt.wantResponse.Class.ID = createdClass.ID
+				tt.wantResponse.Class.LateThresholdMinutes = createdClass.LateThresholdMinutes
+				tt.wantResponse.Class.AttendanceOpensBeforeMinutes = createdClass.AttendanceOpensBeforeMinutes
+				tt.wantResponse.Class.AttendanceClosesAfterMinutes = createdClass.AttendanceClosesAfterMinutes
 				tt.wantResponse.Class.CreatedAt = createdClass.CreatedAt
 				tt.wantResponse.Class.UpdatedAt = createdClass.CreatedAt
 			}
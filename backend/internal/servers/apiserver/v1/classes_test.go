@@ -114,6 +114,9 @@ func TestAPIServerV1_classesGet(t *testing.T) {
 					})
 					classPtr := &tt.wantResponse.Classes[idx]
 					classPtr.ID = createdClass.ID
+					classPtr.LateThresholdMinutes = createdClass.LateThresholdMinutes
+					classPtr.AttendanceOpensBeforeMinutes = createdClass.AttendanceOpensBeforeMinutes
+					classPtr.AttendanceClosesAfterMinutes = createdClass.AttendanceClosesAfterMinutes
 					classPtr.CreatedAt, classPtr.UpdatedAt = createdClass.CreatedAt, createdClass.CreatedAt
 				}
 			}
@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/darylhjd/oams/backend/internal/database"
 	"github.com/darylhjd/oams/backend/pkg/to"
@@ -21,6 +24,8 @@ func (v *APIServerV1) coordinatingClass(w http.ResponseWriter, r *http.Request)
 	switch r.Method {
 	case http.MethodGet:
 		resp = v.coordinatingClassGet(r, classId)
+	case http.MethodPatch:
+		resp = v.coordinatingClassPatch(r, classId)
 	default:
 		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
 	}
@@ -49,3 +54,57 @@ func (v *APIServerV1) coordinatingClassGet(r *http.Request, classId int64) apiRe
 		class,
 	}
 }
+
+type coordinatingClassPatchRequest struct {
+	LateThresholdMinutes         *int32                       `json:"late_threshold_minutes"`
+	AttendanceLockedAfter        database.Nullable[time.Time] `json:"attendance_locked_after"`
+	AttendanceOpensBeforeMinutes *int32                       `json:"attendance_opens_before_minutes"`
+	AttendanceClosesAfterMinutes *int32                       `json:"attendance_closes_after_minutes"`
+}
+
+type coordinatingClassPatchResponse struct {
+	response
+	CoordinatingClass database.CoordinatingClass `json:"coordinating_class"`
+}
+
+func (v *APIServerV1) coordinatingClassPatch(r *http.Request, classId int64) apiResponse {
+	var req coordinatingClassPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	negative := func(minutes *int32) bool {
+		return minutes != nil && *minutes < 0
+	}
+
+	switch {
+	case req.LateThresholdMinutes == nil && !req.AttendanceLockedAfter.Set &&
+		req.AttendanceOpensBeforeMinutes == nil && req.AttendanceClosesAfterMinutes == nil:
+		return newErrorResponse(http.StatusBadRequest, "no class settings to update")
+	case negative(req.LateThresholdMinutes):
+		return newErrorResponse(http.StatusBadRequest, "late threshold cannot be negative")
+	case negative(req.AttendanceOpensBeforeMinutes), negative(req.AttendanceClosesAfterMinutes):
+		return newErrorResponse(http.StatusBadRequest, "attendance window offsets cannot be negative")
+	}
+
+	class, err := v.db.UpdateCoordinatingClass(r.Context(), database.UpdateCoordinatingClassParams{
+		ID:                           classId,
+		LateThresholdMinutes:         req.LateThresholdMinutes,
+		AttendanceLockedAfter:        req.AttendanceLockedAfter,
+		AttendanceOpensBeforeMinutes: req.AttendanceOpensBeforeMinutes,
+		AttendanceClosesAfterMinutes: req.AttendanceClosesAfterMinutes,
+	})
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to update coordinating class")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class patch database action")
+	}
+
+	return coordinatingClassPatchResponse{
+		newSuccessResponse(),
+		class,
+	}
+}
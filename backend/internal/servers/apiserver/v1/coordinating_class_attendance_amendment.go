@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) coordinatingClassAttendanceAmendment(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	amendmentId, err := to.Int64(r.PathValue("amendmentId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid attendance amendment id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		resp = v.coordinatingClassAttendanceAmendmentPatch(r, classId, amendmentId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassAttendanceAmendmentPatchRequest struct {
+	Status        model.AmendmentStatus `json:"status"`
+	ReviewComment string                `json:"review_comment"`
+}
+
+type coordinatingClassAttendanceAmendmentPatchResponse struct {
+	response
+	AttendanceAmendment database.AttendanceAmendment `json:"attendance_amendment"`
+}
+
+// coordinatingClassAttendanceAmendmentPatch approves or rejects a pending attendance amendment. Approving an amendment
+// applies the proposed status to the session enrollment.
+func (v *APIServerV1) coordinatingClassAttendanceAmendmentPatch(r *http.Request, classId, amendmentId int64) apiResponse {
+	var req coordinatingClassAttendanceAmendmentPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	if req.Status != model.AmendmentStatus_Approved && req.Status != model.AmendmentStatus_Rejected {
+		return newErrorResponse(http.StatusBadRequest, "attendance amendment can only be approved or rejected")
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	amendment, err := txDb.ReviewCoordinatingClassAttendanceAmendment(r.Context(), database.ReviewCoordinatingClassAttendanceAmendmentParams{
+		ClassID:       classId,
+		AmendmentID:   amendmentId,
+		Status:        req.Status,
+		ReviewComment: req.ReviewComment,
+	})
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to review attendance amendment or attendance is locked")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class attendance amendment patch database action")
+	}
+
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
+	return coordinatingClassAttendanceAmendmentPatchResponse{
+		newSuccessResponse(),
+		amendment,
+	}
+}
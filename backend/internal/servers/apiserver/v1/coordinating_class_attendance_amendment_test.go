@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/tests"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIServerV1_coordinatingClassAttendanceAmendmentPatch(t *testing.T) {
+	t.Parallel()
+
+	tts := []struct {
+		name                 string
+		withBody             string
+		withLockedAttendance bool
+		wantStatusCode       int
+		wantAmendmentStatus  model.AmendmentStatus
+		wantEnrollmentStatus model.AttendanceStatus
+	}{
+		{
+			"request approving amendment",
+			`{"status": "APPROVED"}`,
+			false,
+			http.StatusOK,
+			model.AmendmentStatus_Approved,
+			model.AttendanceStatus_Late,
+		},
+		{
+			"request rejecting amendment",
+			`{"status": "REJECTED", "review_comment": "student was absent"}`,
+			false,
+			http.StatusOK,
+			model.AmendmentStatus_Rejected,
+			model.AttendanceStatus_Absent,
+		},
+		{
+			"request approving amendment for class with locked attendance",
+			`{"status": "APPROVED"}`,
+			true,
+			http.StatusUnauthorized,
+			model.AmendmentStatus_Pending,
+			model.AttendanceStatus_Absent,
+		},
+		{
+			"request setting amendment to pending",
+			`{"status": "PENDING"}`,
+			false,
+			http.StatusBadRequest,
+			model.AmendmentStatus_Pending,
+			model.AttendanceStatus_Absent,
+		},
+	}
+
+	for _, tt := range tts {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := assert.New(t)
+			ctx := context.WithValue(context.Background(), oauth2.AuthContextKey, tests.StubAuthContext())
+			id := uuid.NewString()
+
+			v1 := newTestAPIServerV1(t, id)
+			defer tests.TearDown(t, v1.db, id)
+
+			tests.StubUser(t, ctx, v1.db, database.CreateUserParams{
+				ID:   tests.MockAuthenticatorUserID,
+				Role: tests.MockAuthenticatorUserRole,
+			})
+
+			enrollment := tests.StubSessionEnrollment(t, ctx, v1.db, model.AttendanceStatus_Absent)
+			amendment, err := v1.db.CreateAttendanceAmendment(ctx, database.CreateAttendanceAmendmentParams{
+				SessionEnrollmentID: enrollment.ID,
+				ProposedStatus:      model.AttendanceStatus_Late,
+				Reason:              "student was late",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.withLockedAttendance {
+				lockClassAttendance(t, ctx, v1.db, enrollment, time.Now().Add(-time.Minute))
+			}
+
+			req := httpRequestWithAuthContext(
+				httptest.NewRequest(http.MethodPatch, coordinatingClassAttendanceAmendmentUrl, strings.NewReader(tt.withBody)),
+				tests.StubAuthContext(),
+			)
+			resp := v1.coordinatingClassAttendanceAmendmentPatch(req, amendment.ClassID, amendment.ID)
+			a.Equal(tt.wantStatusCode, resp.Code())
+
+			if tt.wantStatusCode == http.StatusOK {
+				actualResp, ok := resp.(coordinatingClassAttendanceAmendmentPatchResponse)
+				a.True(ok)
+				a.Equal(tt.wantAmendmentStatus, actualResp.AttendanceAmendment.Status)
+				a.Equal(tests.MockAuthenticatorUserID, *actualResp.AttendanceAmendment.ReviewerID)
+			}
+
+			actualEnrollment, err := v1.db.GetSessionEnrollment(ctx, enrollment.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a.Equal(tt.wantEnrollmentStatus, actualEnrollment.Status)
+			if tt.wantAmendmentStatus == model.AmendmentStatus_Approved {
+				a.Equal(model.AttendanceSource_Manual, actualEnrollment.Source)
+				a.Equal(tests.MockAuthenticatorUserID, *actualEnrollment.MarkedBy)
+				a.NotNil(actualEnrollment.CheckedInAt)
+			}
+
+			amendments, err := v1.db.GetCoordinatingClassAttendanceAmendments(ctx, amendment.ClassID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a.Len(amendments, 1)
+			a.Equal(tt.wantAmendmentStatus, amendments[0].Status)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/pkg/to"
+)
+
+func (v *APIServerV1) coordinatingClassAttendanceAmendments(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.coordinatingClassAttendanceAmendmentsGet(r, classId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassAttendanceAmendmentsGetResponse struct {
+	response
+	AttendanceAmendments []database.AttendanceAmendment `json:"attendance_amendments"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassAttendanceAmendmentsGet(r *http.Request, classId int64) apiResponse {
+	amendments, err := v.db.GetCoordinatingClassAttendanceAmendments(r.Context(), classId)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not get coordinating class attendance amendments")
+	}
+
+	return coordinatingClassAttendanceAmendmentsGetResponse{
+		newSuccessResponse(),
+		append(make([]database.AttendanceAmendment, 0, len(amendments)), amendments...),
+	}
+}
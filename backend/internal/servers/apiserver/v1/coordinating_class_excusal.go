@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) coordinatingClassExcusal(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	excusalId, err := to.Int64(r.PathValue("excusalId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid excusal id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		resp = v.coordinatingClassExcusalPatch(r, classId, excusalId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassExcusalPatchRequest struct {
+	Status        model.ExcusalStatus `json:"status"`
+	ReviewComment string              `json:"review_comment"`
+}
+
+type coordinatingClassExcusalPatchResponse struct {
+	response
+	Excusal database.Excusal `json:"excusal"`
+}
+
+// coordinatingClassExcusalPatch approves or rejects a pending excusal. Approving an excusal marks the covered session
+// enrollments as excused.
+func (v *APIServerV1) coordinatingClassExcusalPatch(r *http.Request, classId, excusalId int64) apiResponse {
+	var req coordinatingClassExcusalPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	if req.Status != model.ExcusalStatus_Approved && req.Status != model.ExcusalStatus_Rejected {
+		return newErrorResponse(http.StatusBadRequest, "excusal can only be approved or rejected")
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	excusal, err := txDb.ReviewCoordinatingClassExcusal(r.Context(), database.ReviewCoordinatingClassExcusalParams{
+		ClassID:       classId,
+		ExcusalID:     excusalId,
+		Status:        req.Status,
+		ReviewComment: req.ReviewComment,
+	})
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to review excusal or excusal already reviewed")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class excusal patch database action")
+	}
+
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
+	return coordinatingClassExcusalPatchResponse{
+		newSuccessResponse(),
+		excusal,
+	}
+}
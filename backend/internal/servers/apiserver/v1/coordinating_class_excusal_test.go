@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/tests"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIServerV1_coordinatingClassExcusalPatch(t *testing.T) {
+	t.Parallel()
+
+	tts := []struct {
+		name                 string
+		withBody             string
+		withReviewedExcusal  bool
+		wantStatusCode       int
+		wantExcusalStatus    model.ExcusalStatus
+		wantEnrollmentStatus model.AttendanceStatus
+	}{
+		{
+			"request approving excusal",
+			`{"status": "APPROVED", "review_comment": "medical certificate"}`,
+			false,
+			http.StatusOK,
+			model.ExcusalStatus_Approved,
+			model.AttendanceStatus_Excused,
+		},
+		{
+			"request rejecting excusal",
+			`{"status": "REJECTED"}`,
+			false,
+			http.StatusOK,
+			model.ExcusalStatus_Rejected,
+			model.AttendanceStatus_Absent,
+		},
+		{
+			"request setting excusal to pending",
+			`{"status": "PENDING"}`,
+			false,
+			http.StatusBadRequest,
+			model.ExcusalStatus_Pending,
+			model.AttendanceStatus_Absent,
+		},
+		{
+			"request approving reviewed excusal",
+			`{"status": "APPROVED"}`,
+			true,
+			http.StatusUnauthorized,
+			model.ExcusalStatus_Rejected,
+			model.AttendanceStatus_Absent,
+		},
+	}
+
+	for _, tt := range tts {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := assert.New(t)
+			ctx := context.WithValue(context.Background(), oauth2.AuthContextKey, tests.StubAuthContext())
+			id := uuid.NewString()
+
+			v1 := newTestAPIServerV1(t, id)
+			defer tests.TearDown(t, v1.db, id)
+
+			tests.StubUser(t, ctx, v1.db, database.CreateUserParams{
+				ID:   tests.MockAuthenticatorUserID,
+				Role: tests.MockAuthenticatorUserRole,
+			})
+
+			enrollment := tests.StubSessionEnrollment(t, ctx, v1.db, model.AttendanceStatus_Absent)
+			session, err := v1.db.GetClassGroupSession(ctx, enrollment.SessionID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			group, err := v1.db.GetClassGroup(ctx, session.ClassGroupID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			studentCtx := context.WithValue(context.Background(), oauth2.AuthContextKey, oauth2.AuthContext{
+				User: model.User{ID: enrollment.UserID, Role: model.UserRole_User},
+			})
+			excusal, err := v1.db.CreateExcusal(studentCtx, database.CreateExcusalParams{
+				Reason:               "unwell",
+				DocumentName:         "mc.pdf",
+				DocumentType:         "application/pdf",
+				Document:             []byte("%PDF-"),
+				SessionEnrollmentIDs: []int64{enrollment.ID},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.withReviewedExcusal {
+				if _, err = v1.db.ReviewCoordinatingClassExcusal(ctx, database.ReviewCoordinatingClassExcusalParams{
+					ClassID:   group.ClassID,
+					ExcusalID: excusal.ID,
+					Status:    model.ExcusalStatus_Rejected,
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := httpRequestWithAuthContext(
+				httptest.NewRequest(http.MethodPatch, coordinatingClassExcusalUrl, strings.NewReader(tt.withBody)),
+				tests.StubAuthContext(),
+			)
+			resp := v1.coordinatingClassExcusalPatch(req, group.ClassID, excusal.ID)
+			a.Equal(tt.wantStatusCode, resp.Code())
+
+			actualExcusal, err := v1.db.GetExcusal(ctx, excusal.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a.Equal(tt.wantExcusalStatus, actualExcusal.Status)
+			a.Len(actualExcusal.Sessions, 1)
+			a.Equal(tt.wantEnrollmentStatus, actualExcusal.Sessions[0].Status)
+
+			actualEnrollment, err := v1.db.GetSessionEnrollment(ctx, enrollment.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a.Equal(tt.wantEnrollmentStatus, actualEnrollment.Status)
+			if tt.wantEnrollmentStatus == model.AttendanceStatus_Excused {
+				a.Equal(model.AttendanceSource_Manual, actualEnrollment.Source)
+				a.Equal(tests.MockAuthenticatorUserID, *actualEnrollment.MarkedBy)
+			}
+		})
+	}
+}
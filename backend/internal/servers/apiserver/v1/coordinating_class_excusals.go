@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/pkg/to"
+)
+
+func (v *APIServerV1) coordinatingClassExcusals(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.coordinatingClassExcusalsGet(r, classId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassExcusalsGetResponse struct {
+	response
+	Excusals []database.Excusal `json:"excusals"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassExcusalsGet(r *http.Request, classId int64) apiResponse {
+	excusals, err := v.db.GetCoordinatingClassExcusals(r.Context(), classId)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not get coordinating class excusals")
+	}
+
+	return coordinatingClassExcusalsGetResponse{
+		newSuccessResponse(),
+		append(make([]database.Excusal, 0, len(excusals)), excusals...),
+	}
+}
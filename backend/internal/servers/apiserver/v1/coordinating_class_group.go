@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) coordinatingClassGroup(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	groupId, err := to.Int64(r.PathValue("groupId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class group id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		resp = v.coordinatingClassGroupPatch(r, classId, groupId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassGroupPatchRequest struct {
+	AttendanceOpensBeforeMinutes database.Nullable[int32] `json:"attendance_opens_before_minutes"`
+	AttendanceClosesAfterMinutes database.Nullable[int32] `json:"attendance_closes_after_minutes"`
+}
+
+type coordinatingClassGroupPatchResponse struct {
+	response
+	ClassGroup database.CoordinatingClassGroup `json:"class_group"`
+}
+
+// coordinatingClassGroupPatch sets the attendance window overrides of a class group. Only the given offsets are
+// updated, and an explicit null offset removes the override so that the class setting is used.
+func (v *APIServerV1) coordinatingClassGroupPatch(r *http.Request, classId, groupId int64) apiResponse {
+	var req coordinatingClassGroupPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	negative := func(minutes database.Nullable[int32]) bool {
+		return minutes.Value != nil && *minutes.Value < 0
+	}
+
+	switch {
+	case !req.AttendanceOpensBeforeMinutes.Set && !req.AttendanceClosesAfterMinutes.Set:
+		return newErrorResponse(http.StatusBadRequest, "no class group settings to update")
+	case negative(req.AttendanceOpensBeforeMinutes), negative(req.AttendanceClosesAfterMinutes):
+		return newErrorResponse(http.StatusBadRequest, "attendance window offsets cannot be negative")
+	}
+
+	group, err := v.db.UpdateCoordinatingClassGroup(r.Context(), database.UpdateCoordinatingClassGroupParams{
+		ClassID:                      classId,
+		GroupID:                      groupId,
+		AttendanceOpensBeforeMinutes: req.AttendanceOpensBeforeMinutes,
+		AttendanceClosesAfterMinutes: req.AttendanceClosesAfterMinutes,
+	})
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to update coordinating class group")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class group patch database action")
+	}
+
+	return coordinatingClassGroupPatchResponse{
+		newSuccessResponse(),
+		group,
+	}
+}
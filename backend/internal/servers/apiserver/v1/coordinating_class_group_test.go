@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/tests"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIServerV1_coordinatingClassGroupPatch(t *testing.T) {
+	t.Parallel()
+
+	tts := []struct {
+		name            string
+		withBody        string
+		wantStatusCode  int
+		wantOpensBefore *int32
+		wantClosesAfter *int32
+	}{
+		{
+			"request updating one override",
+			`{"attendance_opens_before_minutes": 5}`,
+			http.StatusOK,
+			to.Ptr(int32(5)),
+			to.Ptr(int32(20)),
+		},
+		{
+			"request removing one override",
+			`{"attendance_closes_after_minutes": null}`,
+			http.StatusOK,
+			to.Ptr(int32(10)),
+			nil,
+		},
+		{
+			"request updating both overrides",
+			`{"attendance_opens_before_minutes": null, "attendance_closes_after_minutes": 0}`,
+			http.StatusOK,
+			nil,
+			to.Ptr(int32(0)),
+		},
+		{
+			"request with no overrides",
+			`{}`,
+			http.StatusBadRequest,
+			to.Ptr(int32(10)),
+			to.Ptr(int32(20)),
+		},
+		{
+			"request with negative override",
+			`{"attendance_opens_before_minutes": -1}`,
+			http.StatusBadRequest,
+			to.Ptr(int32(10)),
+			to.Ptr(int32(20)),
+		},
+	}
+
+	for _, tt := range tts {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := assert.New(t)
+			ctx := context.WithValue(context.Background(), oauth2.AuthContextKey, tests.StubAuthContext())
+			id := uuid.NewString()
+
+			v1 := newTestAPIServerV1(t, id)
+			defer tests.TearDown(t, v1.db, id)
+
+			group := tests.StubClassGroup(t, ctx, v1.db, uuid.NewString(), model.ClassType_Lec)
+			if _, err := v1.db.UpdateCoordinatingClassGroup(ctx, database.UpdateCoordinatingClassGroupParams{
+				ClassID:                      group.ClassID,
+				GroupID:                      group.ID,
+				AttendanceOpensBeforeMinutes: database.Nullable[int32]{Set: true, Value: to.Ptr(int32(10))},
+				AttendanceClosesAfterMinutes: database.Nullable[int32]{Set: true, Value: to.Ptr(int32(20))},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httpRequestWithAuthContext(
+				httptest.NewRequest(http.MethodPatch, coordinatingClassGroupUrl, strings.NewReader(tt.withBody)),
+				tests.StubAuthContext(),
+			)
+			resp := v1.coordinatingClassGroupPatch(req, group.ClassID, group.ID)
+			a.Equal(tt.wantStatusCode, resp.Code())
+
+			groups, err := v1.db.GetCoordinatingClassGroups(ctx, group.ClassID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a.Len(groups, 1)
+			a.Equal(tt.wantOpensBefore, groups[0].AttendanceOpensBeforeMinutes)
+			a.Equal(tt.wantClosesAfter, groups[0].AttendanceClosesAfterMinutes)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/pkg/to"
+)
+
+func (v *APIServerV1) coordinatingClassGroups(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.coordinatingClassGroupsGet(r, classId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassGroupsGetResponse struct {
+	response
+	ClassGroups []database.CoordinatingClassGroup `json:"class_groups"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassGroupsGet(r *http.Request, classId int64) apiResponse {
+	groups, err := v.db.GetCoordinatingClassGroups(r.Context(), classId)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class groups get database action")
+	}
+
+	return coordinatingClassGroupsGetResponse{
+		newSuccessResponse(),
+		append(make([]database.CoordinatingClassGroup, 0, len(groups)), groups...),
+	}
+}
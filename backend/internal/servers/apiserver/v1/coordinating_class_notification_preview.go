@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/intervention"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) coordinatingClassNotificationPreview(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		resp = v.coordinatingClassNotificationPreviewPost(r, classId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassNotificationPreviewPostRequest struct {
+	intervention.ClassTemplateParams
+}
+
+type coordinatingClassNotificationPreviewPostResponse struct {
+	response
+	Preview intervention.TemplatePreview `json:"preview"`
+}
+
+// coordinatingClassNotificationPreviewPost renders the given notification templates with a sample student who failed
+// the rules of the class, without saving them. Templates that are not given are rendered from the built-in templates.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassNotificationPreviewPost(r *http.Request, classId int64) apiResponse {
+	var req coordinatingClassNotificationPreviewPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	class, err := v.db.GetCoordinatingClass(r.Context(), classId)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested coordinating class does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class get database action")
+	}
+
+	classRules, err := v.db.GetCoordinatingClassRules(r.Context(), classId)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not get coordinating class rules")
+	}
+
+	preview, err := intervention.PreviewClassTemplates(class, classRules, req.ClassTemplateParams)
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("templates failed validation: %s", err))
+	}
+
+	return coordinatingClassNotificationPreviewPostResponse{
+		newSuccessResponse(),
+		preview,
+	}
+}
@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/intervention"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) coordinatingClassNotificationTemplates(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.coordinatingClassNotificationTemplatesGet(r, classId)
+	case http.MethodPut:
+		resp = v.coordinatingClassNotificationTemplatesPut(r, classId)
+	case http.MethodDelete:
+		resp = v.coordinatingClassNotificationTemplatesDelete(r, classId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassNotificationTemplatesGetResponse struct {
+	response
+	NotificationTemplate database.CoordinatingClassNotificationTemplate `json:"notification_template"`
+}
+
+// coordinatingClassNotificationTemplatesGet returns the notification template overrides of a class. Templates that
+// are not overridden are null.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassNotificationTemplatesGet(r *http.Request, classId int64) apiResponse {
+	template, err := v.db.GetCoordinatingClassNotificationTemplate(r.Context(), classId)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested coordinating class does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class notification templates get database action")
+	}
+
+	return coordinatingClassNotificationTemplatesGetResponse{
+		newSuccessResponse(),
+		template,
+	}
+}
+
+type coordinatingClassNotificationTemplatesPutRequest struct {
+	intervention.ClassTemplateParams
+}
+
+type coordinatingClassNotificationTemplatesPutResponse struct {
+	response
+	NotificationTemplate model.ClassNotificationTemplate `json:"notification_template"`
+}
+
+// coordinatingClassNotificationTemplatesPut sets the notification template overrides of a class, replacing any
+// existing overrides. Templates that are not given fall back to the built-in templates.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassNotificationTemplatesPut(r *http.Request, classId int64) apiResponse {
+	var req coordinatingClassNotificationTemplatesPutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	if err := req.Verify(); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("templates failed validation: %s", err))
+	}
+
+	template, err := v.db.UpsertCoordinatingClassNotificationTemplate(r.Context(), database.UpsertCoordinatingClassNotificationTemplateParams{
+		ClassID:                 classId,
+		EditorID:                oauth2.GetAuthContext(r.Context()).User.ID,
+		UserTextTemplate:        req.UserTextTemplate,
+		UserHtmlTemplate:        req.UserHtmlTemplate,
+		RuleCreatorTextTemplate: req.RuleCreatorTextTemplate,
+		RuleCreatorHtmlTemplate: req.RuleCreatorHtmlTemplate,
+	})
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to update coordinating class notification templates")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class notification templates put database action")
+	}
+
+	return coordinatingClassNotificationTemplatesPutResponse{
+		newSuccessResponse(),
+		template,
+	}
+}
+
+type coordinatingClassNotificationTemplatesDeleteResponse struct {
+	response
+}
+
+// coordinatingClassNotificationTemplatesDelete removes the notification template overrides of a class, so that the
+// built-in templates are used.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassNotificationTemplatesDelete(r *http.Request, classId int64) apiResponse {
+	if err := v.db.DeleteCoordinatingClassNotificationTemplate(r.Context(), classId); err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested coordinating class notification templates do not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class notification templates delete database action")
+	}
+
+	return coordinatingClassNotificationTemplatesDeleteResponse{
+		newSuccessResponse(),
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/rules"
 	"github.com/darylhjd/oams/backend/pkg/to"
 	"github.com/go-jet/jet/v2/qrm"
 )
@@ -38,34 +42,133 @@ func (v *APIServerV1) coordinatingClassRule(w http.ResponseWriter, r *http.Reque
 }
 
 type coordinatingClassRulePatchRequest struct {
-	Active bool `json:"active"`
+	Active                   *bool                   `json:"active"`
+	NotificationCooldownDays *int32                  `json:"notification_cooldown_days"`
+	Severity                 *model.RuleSeverity     `json:"severity"`
+	EscalationPolicy         *rules.EscalationPolicy `json:"escalation_policy"`
+	Title                    *string                 `json:"title"`
+	Description              *string                 `json:"description"`
+	Definition               *rules.RuleDefinition   `json:"definition"`
 }
 
 type coordinatingClassRulePatchResponse struct {
 	response
-	Active bool `json:"active"`
+	Active bool                      `json:"active"`
+	Rule   model.ClassAttendanceRule `json:"rule"`
 }
 
+// coordinatingClassRulePatch updates a rule of a class. Changing the title, description or definition of a rule
+// creates a new version of the rule.
+// TODO: Implement tests for this endpoint.
 func (v *APIServerV1) coordinatingClassRulePatch(r *http.Request, classId, ruleId int64) apiResponse {
 	var req coordinatingClassRulePatchRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
 	}
 
-	active, err := v.db.UpdateCoordinatingClassRule(r.Context(), classId, ruleId, req.Active)
+	if req.NotificationCooldownDays != nil && *req.NotificationCooldownDays < 0 {
+		return newErrorResponse(http.StatusBadRequest, "notification cooldown days cannot be negative")
+	}
+
+	if req.Severity != nil {
+		if err := verifyRuleSeverity(*req.Severity); err != nil {
+			return newErrorResponse(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	if req.EscalationPolicy != nil {
+		if err := req.EscalationPolicy.Verify(); err != nil {
+			return newErrorResponse(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
 	if err != nil {
-		if errors.Is(err, qrm.ErrNoRows) {
-			return newErrorResponse(http.StatusUnauthorized, "not allowed to toggle rule active")
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer tx.Rollback()
+
+	params := database.UpdateCoordinatingClassRuleParams{
+		EditorID:                 oauth2.GetAuthContext(r.Context()).User.ID,
+		Active:                   req.Active,
+		NotificationCooldownDays: req.NotificationCooldownDays,
+		Severity:                 req.Severity,
+		EscalationPolicy:         req.EscalationPolicy,
+	}
+
+	if req.Title != nil || req.Description != nil || req.Definition != nil {
+		current, err := txDb.GetCoordinatingClassRule(r.Context(), classId, ruleId)
+		if err != nil {
+			if errors.Is(err, qrm.ErrNoRows) {
+				return newErrorResponse(http.StatusUnauthorized, "not allowed to update rule")
+			}
+
+			v.logInternalServerError(r, err)
+			return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class rule get database action")
+		}
+
+		if params.Definition, err = ruleDefinitionUpdate(req, current); err != nil {
+			return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("rule failed validation: %s", err))
 		}
+	}
 
+	rule, err := txDb.UpdateCoordinatingClassRule(r.Context(), classId, ruleId, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, qrm.ErrNoRows):
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to update rule")
+		case database.ErrSQLState(err, database.SQLStateDuplicateKeyOrIndex):
+			return newErrorResponse(http.StatusConflict, "rule with same title already exists")
+		default:
+			v.logInternalServerError(r, err)
+			return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class rule patch database action")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
 		v.logInternalServerError(r, err)
-		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class rule patch database action")
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
 	}
 
 	return coordinatingClassRulePatchResponse{
 		newSuccessResponse(),
-		active,
+		rule.Active,
+		rule,
+	}
+}
+
+// ruleDefinitionUpdate merges the requested changes into the current definition of a rule and verifies the result.
+// The rule is only recompiled if a new definition is given.
+func ruleDefinitionUpdate(req coordinatingClassRulePatchRequest, current model.ClassAttendanceRule) (*database.UpdateCoordinatingClassRuleDefinitionParams, error) {
+	params := rules.RuleParams{
+		Title:       current.Title,
+		Description: current.Description,
+	}
+
+	if req.Title != nil {
+		params.Title = *req.Title
+	}
+
+	if req.Description != nil {
+		params.Description = *req.Description
+	}
+
+	definition := &database.UpdateCoordinatingClassRuleDefinitionParams{
+		Title:       params.Title,
+		Description: params.Description,
+		Rule:        current.Rule,
+		Env:         current.Environment.Env,
+	}
+
+	if req.Definition == nil {
+		return definition, params.VerifyDetails()
 	}
+
+	var err error
+	params.RuleDefinition = *req.Definition
+	definition.Rule, definition.Env, err = params.Verify()
+	return definition, err
 }
 
 type coordinatingClassRuleDeleteResponse struct {
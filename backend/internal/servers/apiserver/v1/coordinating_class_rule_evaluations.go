@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/pkg/to"
+)
+
+func (v *APIServerV1) coordinatingClassRuleEvaluations(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.coordinatingClassRuleEvaluationsGet(r, classId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassRuleEvaluationsGetResponse struct {
+	response
+	RuleEvaluations []database.CoordinatingClassRuleEvaluation `json:"rule_evaluations"`
+}
+
+// coordinatingClassRuleEvaluationsGet returns the evaluation history of the rules of a class. The history can be
+// filtered to a student with the user_id query parameter, and to a rule with the rule_id query parameter.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassRuleEvaluationsGet(r *http.Request, classId int64) apiResponse {
+	params, err := database.DecodeListRuleEvaluationsParams(r.URL.Query())
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	evaluations, err := v.db.GetCoordinatingClassRuleEvaluations(r.Context(), classId, params)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not get coordinating class rule evaluations")
+	}
+
+	return coordinatingClassRuleEvaluationsGetResponse{
+		newSuccessResponse(),
+		append(make([]database.CoordinatingClassRuleEvaluation, 0, len(evaluations)), evaluations...),
+	}
+}
@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/pkg/to"
+)
+
+func (v *APIServerV1) coordinatingClassRuleVersions(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	ruleId, err := to.Int64(r.PathValue("ruleId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid rule id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.coordinatingClassRuleVersionsGet(r, classId, ruleId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassRuleVersionsGetResponse struct {
+	response
+	Versions []model.ClassAttendanceRuleVersion `json:"versions"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassRuleVersionsGet(r *http.Request, classId, ruleId int64) apiResponse {
+	versions, err := v.db.GetCoordinatingClassRuleVersions(r.Context(), classId, ruleId)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not get coordinating class rule versions")
+	}
+
+	return coordinatingClassRuleVersionsGetResponse{
+		newSuccessResponse(),
+		append(make([]model.ClassAttendanceRuleVersion, 0, len(versions)), versions...),
+	}
+}
@@ -54,8 +54,60 @@ func (v *APIServerV1) coordinatingClassRulesGet(r *http.Request, classId int64)
 	}
 }
 
+const (
+	defaultRuleNotificationCooldownDays = 7
+)
+
+// ruleNotificationParams are the optional notification settings that can be given when creating a rule.
+type ruleNotificationParams struct {
+	NotificationCooldownDays *int32                  `json:"notification_cooldown_days"`
+	Severity                 *model.RuleSeverity     `json:"severity"`
+	EscalationPolicy         *rules.EscalationPolicy `json:"escalation_policy"`
+}
+
+// verify checks the notification settings, and returns them with defaults for the settings that are not given.
+func (p ruleNotificationParams) verify() (cooldownDays int32, severity model.RuleSeverity, policy rules.EscalationPolicy, err error) {
+	cooldownDays, severity = defaultRuleNotificationCooldownDays, model.RuleSeverity_Warning
+
+	if p.NotificationCooldownDays != nil {
+		if *p.NotificationCooldownDays < 0 {
+			return 0, "", policy, errors.New("notification cooldown days cannot be negative")
+		}
+
+		cooldownDays = *p.NotificationCooldownDays
+	}
+
+	if p.Severity != nil {
+		if err = verifyRuleSeverity(*p.Severity); err != nil {
+			return 0, "", policy, err
+		}
+
+		severity = *p.Severity
+	}
+
+	if p.EscalationPolicy != nil {
+		if err = p.EscalationPolicy.Verify(); err != nil {
+			return 0, "", policy, err
+		}
+
+		policy = *p.EscalationPolicy
+	}
+
+	return cooldownDays, severity, policy, nil
+}
+
+func verifyRuleSeverity(severity model.RuleSeverity) error {
+	switch severity {
+	case model.RuleSeverity_Info, model.RuleSeverity_Warning, model.RuleSeverity_Critical:
+		return nil
+	default:
+		return fmt.Errorf("unknown rule severity %q", severity)
+	}
+}
+
 type coordinatingClassRulesPostRequest struct {
 	rules.RuleParams
+	ruleNotificationParams
 }
 
 type coordinatingClassRulesPostResponse struct {
@@ -74,13 +126,28 @@ func (v *APIServerV1) coordinatingClassRulesPost(r *http.Request, classId int64)
 		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("rule failed validation: %s", err))
 	}
 
-	rule, err := v.db.CreateNewCoordinatingClassRule(r.Context(), database.CreateNewCoordinatingClassRuleParams{
-		ClassID:     classId,
-		CreatorID:   oauth2.GetAuthContext(r.Context()).User.ID,
-		Title:       req.Title,
-		Description: req.Description,
-		Rule:        ruleString,
-		Env:         env,
+	cooldownDays, severity, policy, err := req.ruleNotificationParams.verify()
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer tx.Rollback()
+
+	rule, err := txDb.CreateNewCoordinatingClassRule(r.Context(), database.CreateNewCoordinatingClassRuleParams{
+		ClassID:                  classId,
+		CreatorID:                oauth2.GetAuthContext(r.Context()).User.ID,
+		Title:                    req.Title,
+		Description:              req.Description,
+		Rule:                     ruleString,
+		Env:                      env,
+		NotificationCooldownDays: cooldownDays,
+		Severity:                 severity,
+		EscalationPolicy:         policy,
 	})
 	if err != nil {
 		switch {
@@ -94,6 +161,11 @@ func (v *APIServerV1) coordinatingClassRulesPost(r *http.Request, classId int64)
 		}
 	}
 
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
 	return coordinatingClassRulesPostResponse{
 		newSuccessResponse(),
 		rule,
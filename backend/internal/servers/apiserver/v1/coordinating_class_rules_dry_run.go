@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/intervention"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) coordinatingClassRulesDryRun(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		resp = v.coordinatingClassRulesDryRunPost(r, classId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassRulesDryRunPostRequest struct {
+	rules.RuleParams
+	// Until is a Unix timestamp in milliseconds. Only sessions that ended before this time are considered. If it is not
+	// given, all sessions that have ended are considered.
+	Until int64 `json:"until"`
+}
+
+type coordinatingClassRulesDryRunPostResponse struct {
+	response
+	Until          time.Time                  `json:"until"`
+	EvaluatedCount int                        `json:"evaluated_count"`
+	FlaggedUsers   []intervention.FlaggedUser `json:"flagged_users"`
+}
+
+// coordinatingClassRulesDryRunPost evaluates a rule against the attendance of a class without creating the rule, and
+// returns the students who would trigger it.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassRulesDryRunPost(r *http.Request, classId int64) apiResponse {
+	var req coordinatingClassRulesDryRunPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	if _, err := v.db.GetCoordinatingClass(r.Context(), classId); err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested coordinating class does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class get database action")
+	}
+
+	ruleString, env, err := req.Verify()
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("rule failed validation: %s", err))
+	}
+
+	until := time.Now()
+	if req.Until != 0 && time.UnixMilli(req.Until).Before(until) {
+		until = time.UnixMilli(req.Until)
+	}
+	until = until.In(datetime.Location)
+
+	facts, err := v.db.GetCoordinatingClassFacts(r.Context(), classId, until)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not get coordinating class facts")
+	}
+
+	flagged, evaluated, err := intervention.DryRun(ruleString, env, facts)
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("rule could not be evaluated: %s", err))
+	}
+
+	return coordinatingClassRulesDryRunPostResponse{
+		newSuccessResponse(),
+		until,
+		evaluated,
+		flagged,
+	}
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) coordinatingClassRulesFromTemplate(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	classId, err := to.Int64(r.PathValue("classId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		resp = v.coordinatingClassRulesFromTemplatePost(r, classId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type coordinatingClassRulesFromTemplatePostRequest struct {
+	TemplateID int64 `json:"template_id"`
+	ruleNotificationParams
+}
+
+type coordinatingClassRulesFromTemplatePostResponse struct {
+	response
+	Rule model.ClassAttendanceRule `json:"rule"`
+}
+
+// coordinatingClassRulesFromTemplatePost instantiates a rule template into a class. The new rule stays linked to the
+// template, so that later updates to the template can be propagated to it.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) coordinatingClassRulesFromTemplatePost(r *http.Request, classId int64) apiResponse {
+	var req coordinatingClassRulesFromTemplatePostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	cooldownDays, severity, policy, err := req.ruleNotificationParams.verify()
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	template, err := v.db.GetRuleTemplate(r.Context(), req.TemplateID)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested rule template does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process rule template get database action")
+	}
+
+	ruleString, env, err := rules.RuleParams{
+		Title:          template.Title,
+		Description:    template.Description,
+		RuleDefinition: template.Definition,
+	}.Verify()
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("rule template failed validation: %s", err))
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer tx.Rollback()
+
+	rule, err := txDb.CreateNewCoordinatingClassRule(r.Context(), database.CreateNewCoordinatingClassRuleParams{
+		ClassID:                  classId,
+		CreatorID:                oauth2.GetAuthContext(r.Context()).User.ID,
+		Title:                    template.Title,
+		Description:              template.Description,
+		Rule:                     ruleString,
+		Env:                      env,
+		NotificationCooldownDays: cooldownDays,
+		TemplateID:               &template.ID,
+		Severity:                 severity,
+		EscalationPolicy:         policy,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, qrm.ErrNoRows):
+			return newErrorResponse(http.StatusBadRequest, "not allowed to create new rule")
+		case database.ErrSQLState(err, database.SQLStateDuplicateKeyOrIndex):
+			return newErrorResponse(http.StatusConflict, "rule with same title already exists")
+		default:
+			v.logInternalServerError(r, err)
+			return newErrorResponse(http.StatusInternalServerError, "could not process coordinating class rules from template post database action")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
+	return coordinatingClassRulesFromTemplatePostResponse{
+		newSuccessResponse(),
+		rule,
+	}
+}
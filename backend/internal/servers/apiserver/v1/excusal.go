@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) excusal(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	excusalId, err := to.Int64(r.PathValue("excusalId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid excusal id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.excusalGet(r, excusalId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type excusalGetResponse struct {
+	response
+	Excusal database.Excusal `json:"excusal"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) excusalGet(r *http.Request, excusalId int64) apiResponse {
+	excusal, err := v.db.GetExcusal(r.Context(), excusalId)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested excusal does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process excusal get database action")
+	}
+
+	return excusalGetResponse{
+		newSuccessResponse(),
+		excusal,
+	}
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) excusalDocument(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	excusalId, err := to.Int64(r.PathValue("excusalId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid excusal id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		// Special case for file download, cannot use v.writeResponse helper.
+		if err := v.excusalDocumentGet(w, r, excusalId); err != nil {
+			resp = *err
+		} else {
+			return
+		}
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) excusalDocumentGet(w http.ResponseWriter, r *http.Request, excusalId int64) *errorResponse {
+	excusal, err := v.db.GetExcusalDocument(r.Context(), excusalId)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return to.Ptr(newErrorResponse(http.StatusNotFound, "the requested excusal does not exist"))
+		}
+
+		v.logInternalServerError(r, err)
+		return to.Ptr(newErrorResponse(http.StatusInternalServerError, "could not process excusal document get database action"))
+	}
+
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": excusal.DocumentName,
+	}))
+	w.Header().Set("Content-Type", excusal.DocumentType)
+
+	if _, err = w.Write(excusal.Document); err != nil {
+		v.logInternalServerError(r, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+const (
+	maxExcusalsPostParseMemory             = 8 << 20
+	maxExcusalDocumentSize                 = 5 << 20
+	multipartFormExcusalReasonIdent        = "reason"
+	multipartFormExcusalEnrollmentIdsIdent = "session-enrollment-ids"
+	multipartFormExcusalDocumentIdent      = "document"
+)
+
+// allowedExcusalDocumentTypes lists the content types that are accepted as supporting documents for an excusal.
+var allowedExcusalDocumentTypes = []string{
+	"application/pdf",
+	"image/jpeg",
+	"image/png",
+}
+
+func (v *APIServerV1) excusals(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.excusalsGet(r)
+	case http.MethodPost:
+		resp = v.excusalsPost(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type excusalsGetResponse struct {
+	response
+	Excusals []database.Excusal `json:"excusals"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) excusalsGet(r *http.Request) apiResponse {
+	excusals, err := v.db.GetExcusals(r.Context())
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process excusals get database action")
+	}
+
+	return excusalsGetResponse{
+		newSuccessResponse(),
+		append(make([]database.Excusal, 0, len(excusals)), excusals...),
+	}
+}
+
+type excusalsPostResponse struct {
+	response
+	Excusal database.Excusal `json:"excusal"`
+}
+
+// excusalsPost creates an excusal for the current user. The request must be a multipart form containing the reason,
+// the session enrollments to be excused and a supporting document.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) excusalsPost(r *http.Request) apiResponse {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart") {
+		return newErrorResponse(http.StatusUnsupportedMediaType, "a multipart request body is required")
+	}
+
+	params, errResp := v.parseExcusalsPostRequest(r)
+	if errResp != nil {
+		return *errResp
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	excusal, err := txDb.CreateExcusal(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusBadRequest, "session enrollments must be your own and belong to the same class")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process excusals post database action")
+	}
+
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
+	return excusalsPostResponse{
+		newSuccessResponse(),
+		excusal,
+	}
+}
+
+func (v *APIServerV1) parseExcusalsPostRequest(r *http.Request) (database.CreateExcusalParams, *errorResponse) {
+	var params database.CreateExcusalParams
+
+	if err := r.ParseMultipartForm(maxExcusalsPostParseMemory); err != nil {
+		return params, to.Ptr(newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err)))
+	}
+
+	reasonField := r.MultipartForm.Value[multipartFormExcusalReasonIdent]
+	if len(reasonField) != 1 || strings.TrimSpace(reasonField[0]) == "" {
+		return params, to.Ptr(newErrorResponse(http.StatusBadRequest, "a reason is required"))
+	}
+	params.Reason = strings.TrimSpace(reasonField[0])
+
+	enrollmentIdsField := r.MultipartForm.Value[multipartFormExcusalEnrollmentIdsIdent]
+	if len(enrollmentIdsField) == 0 {
+		return params, to.Ptr(newErrorResponse(http.StatusBadRequest, "at least one session enrollment is required"))
+	}
+
+	for _, field := range enrollmentIdsField {
+		id, err := to.Int64(field)
+		if err != nil {
+			return params, to.Ptr(newErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid session enrollment id %q", field)))
+		}
+
+		params.SessionEnrollmentIDs = append(params.SessionEnrollmentIDs, id)
+	}
+
+	documentField := r.MultipartForm.File[multipartFormExcusalDocumentIdent]
+	switch {
+	case len(documentField) != 1:
+		return params, to.Ptr(newErrorResponse(http.StatusBadRequest, "exactly one supporting document is required"))
+	case documentField[0].Size > maxExcusalDocumentSize:
+		return params, to.Ptr(newErrorResponse(http.StatusRequestEntityTooLarge, "supporting document is too large"))
+	}
+
+	file, err := documentField[0].Open()
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return params, to.Ptr(newErrorResponse(http.StatusInternalServerError, "could not open supporting document"))
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if params.Document, err = io.ReadAll(file); err != nil {
+		v.logInternalServerError(r, err)
+		return params, to.Ptr(newErrorResponse(http.StatusInternalServerError, "could not read supporting document"))
+	}
+
+	// Do not trust the content type given by the client.
+	params.DocumentName = documentField[0].Filename
+	params.DocumentType = http.DetectContentType(params.Document)
+	if !slices.Contains(allowedExcusalDocumentTypes, params.DocumentType) {
+		return params, to.Ptr(newErrorResponse(http.StatusUnsupportedMediaType, fmt.Sprintf("unsupported supporting document type %q", params.DocumentType)))
+	}
+
+	return params, nil
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) globalRule(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	ruleId, err := to.Int64(r.PathValue("ruleId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid global rule id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		resp = v.globalRulePatch(r, ruleId)
+	case http.MethodDelete:
+		resp = v.globalRuleDelete(r, ruleId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type globalRulePatchRequest struct {
+	Active *bool `json:"active"`
+}
+
+type globalRulePatchResponse struct {
+	response
+	GlobalRule model.GlobalAttendanceRule `json:"global_rule"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) globalRulePatch(r *http.Request, id int64) apiResponse {
+	var req globalRulePatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	rule, err := v.db.UpdateGlobalRule(r.Context(), id, database.UpdateGlobalRuleParams{
+		Active: req.Active,
+	})
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested global rule does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process global rule patch database action")
+	}
+
+	return globalRulePatchResponse{
+		newSuccessResponse(),
+		rule,
+	}
+}
+
+type globalRuleDeleteResponse struct {
+	response
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) globalRuleDelete(r *http.Request, id int64) apiResponse {
+	if err := v.db.DeleteGlobalRule(r.Context(), id); err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested global rule does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process global rule delete database action")
+	}
+
+	return globalRuleDeleteResponse{
+		newSuccessResponse(),
+	}
+}
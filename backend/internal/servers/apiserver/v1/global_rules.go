@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/rules"
+)
+
+func (v *APIServerV1) globalRules(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.globalRulesGet(r)
+	case http.MethodPost:
+		resp = v.globalRulesPost(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type globalRulesGetResponse struct {
+	response
+	GlobalRules []database.GlobalRuleInfo `json:"global_rules"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) globalRulesGet(r *http.Request) apiResponse {
+	globalRules, err := v.db.GetGlobalRules(r.Context())
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process global rules get database action")
+	}
+
+	return globalRulesGetResponse{
+		newSuccessResponse(),
+		append(make([]database.GlobalRuleInfo, 0, len(globalRules)), globalRules...),
+	}
+}
+
+type globalRulesPostRequest struct {
+	rules.RuleParams
+}
+
+type globalRulesPostResponse struct {
+	response
+	GlobalRule model.GlobalAttendanceRule `json:"global_rule"`
+}
+
+// globalRulesPost creates a global rule, which is evaluated over the facts of each student across all classes.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) globalRulesPost(r *http.Request) apiResponse {
+	var req globalRulesPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	ruleString, env, err := req.Verify()
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("rule failed validation: %s", err))
+	}
+
+	rule, err := v.db.CreateGlobalRule(r.Context(), database.CreateGlobalRuleParams{
+		CreatorID:   oauth2.GetAuthContext(r.Context()).User.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		Rule:        ruleString,
+		Env:         env,
+	})
+	if err != nil {
+		if database.ErrSQLState(err, database.SQLStateDuplicateKeyOrIndex) {
+			return newErrorResponse(http.StatusConflict, "global rule with same title already exists")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process global rules post database action")
+	}
+
+	return globalRulesPostResponse{
+		newSuccessResponse(),
+		rule,
+	}
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/intervention"
+	"github.com/darylhjd/oams/backend/pkg/to"
+)
+
+const (
+	interventionPreviewClassIdQueryParam = "class_id"
+	interventionPreviewDateQueryParam    = "date"
+)
+
+func (v *APIServerV1) interventionPreview(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.interventionPreviewGet(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type interventionPreviewGetResponse struct {
+	response
+	Mails []intervention.MailPreview `json:"mails"`
+}
+
+// interventionPreviewGet returns the mails that an intervention run would send, without sending them. The preview is
+// made now, or at the end of the given date, and can be restricted to the rules of one class.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) interventionPreviewGet(r *http.Request) apiResponse {
+	var classId *int64
+	if param := r.URL.Query().Get(interventionPreviewClassIdQueryParam); param != "" {
+		id, err := to.Int64(param)
+		if err != nil {
+			return newErrorResponse(http.StatusUnprocessableEntity, "invalid class id")
+		}
+
+		classId = &id
+	}
+
+	at, err := intervention.PreviewTime(r.URL.Query().Get(interventionPreviewDateQueryParam), time.Now())
+	if err != nil {
+		return newErrorResponse(http.StatusUnprocessableEntity, fmt.Sprintf("invalid date: %s", err))
+	}
+
+	mails, err := intervention.Preview(r.Context(), v.l, v.db, intervention.PreviewParams{
+		At:      at,
+		ClassID: classId,
+	})
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not generate intervention preview")
+	}
+
+	return interventionPreviewGetResponse{
+		newSuccessResponse(),
+		mails,
+	}
+}
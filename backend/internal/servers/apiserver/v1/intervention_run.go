@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+const (
+	interventionRunRecipientQueryParam = "recipient"
+)
+
+func (v *APIServerV1) interventionRun(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	runId, err := to.Int64(r.PathValue("runId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid intervention run id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.interventionRunGet(r, runId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type interventionRunGetResponse struct {
+	response
+	InterventionRun database.InterventionRunInfo       `json:"intervention_run"`
+	RuleFailures    []model.InterventionRunRuleFailure `json:"rule_failures"`
+	Mails           []model.InterventionRunMail        `json:"mails"`
+}
+
+// interventionRunGet returns an intervention run with the rules that failed and the mails sent in the run. The mails
+// can be filtered to those with a recipient containing the given value.
+func (v *APIServerV1) interventionRunGet(r *http.Request, id int64) apiResponse {
+	run, err := v.db.GetInterventionRun(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested intervention run does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process intervention run get database action")
+	}
+
+	failures, err := v.db.GetInterventionRunRuleFailures(r.Context(), id)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process intervention run get database action")
+	}
+
+	var recipient *string
+	if param := r.URL.Query().Get(interventionRunRecipientQueryParam); param != "" {
+		recipient = &param
+	}
+
+	mails, err := v.db.GetInterventionRunMails(r.Context(), id, recipient)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process intervention run get database action")
+	}
+
+	return interventionRunGetResponse{
+		newSuccessResponse(),
+		run,
+		append(make([]model.InterventionRunRuleFailure, 0, len(failures)), failures...),
+		append(make([]model.InterventionRunMail, 0, len(mails)), mails...),
+	}
+}
@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/tests"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIServerV1_interventionRunGet(t *testing.T) {
+	t.Parallel()
+
+	tts := []struct {
+		name            string
+		withExistingRun bool
+		withRecipient   string
+		wantStatusCode  int
+		wantSubjects    []string
+	}{
+		{
+			"request for run",
+			true,
+			"",
+			http.StatusOK,
+			[]string{"OAMS: Attendance Check Failure", "OAMS: Attendance Reminder"},
+		},
+		{
+			"request for run with recipient",
+			true,
+			"USER2@",
+			http.StatusOK,
+			[]string{"OAMS: Attendance Reminder"},
+		},
+		{
+			"request for run with cc recipient",
+			true,
+			"coordinator",
+			http.StatusOK,
+			[]string{"OAMS: Attendance Check Failure"},
+		},
+		{
+			"request for run with wildcard recipient",
+			true,
+			"%",
+			http.StatusOK,
+			[]string{},
+		},
+		{
+			"request for non-existent run",
+			false,
+			"",
+			http.StatusNotFound,
+			nil,
+		},
+	}
+
+	for _, tt := range tts {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := assert.New(t)
+			ctx := context.WithValue(context.Background(), oauth2.AuthContextKey, tests.StubAuthContext())
+			id := uuid.NewString()
+
+			v1 := newTestAPIServerV1(t, id)
+			defer tests.TearDown(t, v1.db, id)
+
+			user := tests.StubUser(t, ctx, v1.db, database.CreateUserParams{
+				ID:   tests.MockAuthenticatorUserID,
+				Name: "Admin",
+				Role: tests.MockAuthenticatorUserRole,
+			})
+
+			var runId int64
+			if tt.withExistingRun {
+				run, err := v1.db.CreateInterventionRun(ctx, database.CreateInterventionRunParams{
+					ScheduledFor: time.Now(),
+					TriggeredBy:  &user.ID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err = v1.db.CreateInterventionRunRuleFailures(ctx, run.ID, []database.CreateInterventionRunRuleFailureParams{
+					{RuleTitle: "Missed sessions", Reason: "could not evaluate rule"},
+				}); err != nil {
+					t.Fatal(err)
+				}
+
+				if err = v1.db.CreateInterventionRunMails(ctx, run.ID, []database.CreateInterventionRunMailParams{
+					{
+						ToAddresses: "user1@example.com",
+						CcAddresses: "coordinator@example.com",
+						Subject:     "OAMS: Attendance Check Failure",
+						Status:      model.MailDeliveryStatus_Sent,
+					},
+					{
+						ToAddresses: "user2@example.com",
+						Subject:     "OAMS: Attendance Reminder",
+						Status:      model.MailDeliveryStatus_Failed,
+						Error:       to.Ptr("mailbox unavailable"),
+					},
+				}); err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err = v1.db.EndInterventionRun(ctx, run.ID, database.EndInterventionRunParams{
+					NumFacts:       3,
+					NumRules:       2,
+					NumEvaluations: 2,
+					NumMails:       2,
+					NumFailedMails: 1,
+				}); err != nil {
+					t.Fatal(err)
+				}
+
+				runId = run.ID
+			}
+
+			target := interventionRunUrl
+			if tt.withRecipient != "" {
+				target += "?" + url.Values{interventionRunRecipientQueryParam: {tt.withRecipient}}.Encode()
+			}
+
+			req := httpRequestWithAuthContext(httptest.NewRequest(http.MethodGet, target, nil), tests.StubAuthContext())
+			resp := v1.interventionRunGet(req, runId)
+			a.Equal(tt.wantStatusCode, resp.Code())
+
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			actualResp, ok := resp.(interventionRunGetResponse)
+			a.True(ok)
+			a.Equal(runId, actualResp.InterventionRun.ID)
+			a.Equal(&user.ID, actualResp.InterventionRun.TriggeredBy)
+			a.Equal(&user.Name, actualResp.InterventionRun.TriggeredByName)
+			a.NotNil(actualResp.InterventionRun.EndedAt)
+			a.Nil(actualResp.InterventionRun.Error)
+			a.Equal(int32(3), actualResp.InterventionRun.NumFacts)
+			a.Equal(int32(2), actualResp.InterventionRun.NumMails)
+			a.Equal(int32(1), actualResp.InterventionRun.NumFailedMails)
+
+			a.Len(actualResp.RuleFailures, 1)
+			a.Equal("could not evaluate rule", actualResp.RuleFailures[0].Reason)
+
+			subjects := make([]string, 0, len(actualResp.Mails))
+			for _, mail := range actualResp.Mails {
+				subjects = append(subjects, mail.Subject)
+			}
+			a.Equal(tt.wantSubjects, subjects)
+		})
+	}
+}
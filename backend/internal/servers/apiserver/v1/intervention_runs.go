@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+	"github.com/darylhjd/oams/backend/internal/intervention"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/pkg/datetime"
+)
+
+func (v *APIServerV1) interventionRuns(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.interventionRunsGet(r)
+	case http.MethodPost:
+		resp = v.interventionRunsPost(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type interventionRunsGetResponse struct {
+	response
+	InterventionRuns []database.InterventionRunInfo `json:"intervention_runs"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) interventionRunsGet(r *http.Request) apiResponse {
+	params, err := database.DecodeListQueryParams(r.URL.Query(), table.InterventionRuns.AllColumns)
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	runs, err := v.db.GetInterventionRuns(r.Context(), params)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process intervention runs get database action")
+	}
+
+	return interventionRunsGetResponse{
+		newSuccessResponse(),
+		append(make([]database.InterventionRunInfo, 0, len(runs)), runs...),
+	}
+}
+
+type interventionRunsPostRequest struct {
+	Date int64 `json:"date"`
+}
+
+type interventionRunsPostResponse struct {
+	response
+	InterventionRun model.InterventionRun `json:"intervention_run"`
+}
+
+// interventionRunsPost manually triggers an intervention run for the day of the given date. The run checks the
+// sessions of the whole day, or of the day so far if the date is today. A failed run is still recorded and returned.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) interventionRunsPost(r *http.Request) apiResponse {
+	var req interventionRunsPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	now := time.Now().In(datetime.Location)
+	date := time.UnixMilli(req.Date).In(datetime.Location)
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, datetime.Location)
+	if startOfDay.After(now) {
+		return newErrorResponse(http.StatusBadRequest, "cannot run intervention for a future date")
+	}
+
+	// The last instant of the day, so that sessions ending at midnight are still checked.
+	at := startOfDay.AddDate(0, 0, 1).Add(-time.Microsecond)
+	if at.After(now) {
+		at = now
+	}
+
+	// The run should not be interrupted if the client disconnects.
+	run, err := intervention.Rerun(
+		context.WithoutCancel(r.Context()), v.l, v.db, at, oauth2.GetAuthContext(r.Context()).User.ID,
+	)
+	switch {
+	case errors.Is(err, intervention.ErrMailNotConfigured):
+		return newErrorResponse(http.StatusServiceUnavailable, "mail is not configured for intervention runs")
+	case errors.Is(err, intervention.ErrRunInProgress):
+		return newErrorResponse(http.StatusConflict, "another intervention run is in progress")
+	case err != nil && run.ID == 0:
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start intervention run")
+	}
+
+	return interventionRunsPostResponse{
+		newSuccessResponse(),
+		run,
+	}
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/rules"
+)
+
+func (v *APIServerV1) meAttendance(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.meAttendanceGet(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type meAttendanceGetResponse struct {
+	response
+	Classes []classAttendanceStanding `json:"classes"`
+}
+
+// classAttendanceStanding contains the attendance of a student for a class, and how close the student is to
+// triggering each of the active rules of the class.
+type classAttendanceStanding struct {
+	ClassID       int64  `json:"class_id"`
+	ClassCode     string `json:"class_code"`
+	ClassYear     int32  `json:"class_year"`
+	ClassSemester string `json:"class_semester"`
+	attendanceCount
+	ClassTypes []classTypeAttendance `json:"class_types"`
+	Rules      []ruleStanding        `json:"rules"`
+
+	past, upcoming []rules.Fact
+}
+
+type classTypeAttendance struct {
+	ClassType string `json:"class_type"`
+	attendanceCount
+}
+
+// attendanceCount counts the attendance of sessions that have ended. Excused sessions do not count towards the total.
+type attendanceCount struct {
+	Attended   int      `json:"attended"`
+	Excused    int      `json:"excused"`
+	Total      int      `json:"total"`
+	Percentage *float64 `json:"percentage"`
+}
+
+func (c *attendanceCount) add(fact rules.Fact) {
+	switch {
+	case fact.Status == string(model.AttendanceStatus_Excused):
+		c.Excused++
+		return
+	case fact.Attended:
+		c.Attended++
+	}
+
+	c.Total++
+	percentage := float64(c.Attended) / float64(c.Total) * 100
+	c.Percentage = &percentage
+}
+
+type ruleStanding struct {
+	RuleID      int64  `json:"rule_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	rules.Standing
+}
+
+// meAttendanceGet returns the attendance of the current user for each enrolled class, grouped by class type. Each
+// active rule of a class is evaluated to find how many more absences the rule allows before it triggers.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) meAttendanceGet(r *http.Request) apiResponse {
+	facts, classRules, err := v.db.GetMyAttendance(r.Context())
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process me attendance get database action")
+	}
+
+	classes := groupAttendanceStandings(facts, time.Now())
+	classIndex := make(map[int64]int, len(classes))
+	for idx, class := range classes {
+		classIndex[class.ClassID] = idx
+	}
+
+	for _, rule := range classRules {
+		idx, ok := classIndex[rule.ClassID]
+		if !ok {
+			continue
+		}
+
+		class := &classes[idx]
+		standing, err := rules.EvaluateStanding(rule.Rule, rule.Environment.Env, class.past, class.upcoming)
+		if err != nil {
+			v.l.Warn(fmt.Sprintf("%s - could not evaluate rule standing", namespace),
+				zap.Int64("rule_id", rule.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		class.Rules = append(class.Rules, ruleStanding{rule.ID, rule.Title, rule.Description, standing})
+	}
+
+	return meAttendanceGetResponse{
+		newSuccessResponse(),
+		classes,
+	}
+}
+
+// groupAttendanceStandings groups facts by class and then by class type. The facts must be ordered by class and then
+// by session. Sessions that have not ended by the given time are upcoming and do not count towards attendance.
+func groupAttendanceStandings(facts []rules.Fact, now time.Time) []classAttendanceStanding {
+	classes := make([]classAttendanceStanding, 0)
+
+	for _, fact := range facts {
+		if len(classes) == 0 || classes[len(classes)-1].ClassID != fact.ClassID {
+			classes = append(classes, classAttendanceStanding{
+				ClassID:       fact.ClassID,
+				ClassCode:     fact.ClassCode,
+				ClassYear:     fact.ClassYear,
+				ClassSemester: fact.ClassSemester,
+				ClassTypes:    []classTypeAttendance{},
+				Rules:         []ruleStanding{},
+			})
+		}
+
+		class := &classes[len(classes)-1]
+		if fact.EndTime.After(now) {
+			class.upcoming = append(class.upcoming, fact)
+			continue
+		}
+
+		class.past = append(class.past, fact)
+		class.add(fact)
+
+		typeIdx := -1
+		for idx := range class.ClassTypes {
+			if class.ClassTypes[idx].ClassType == fact.ClassType {
+				typeIdx = idx
+				break
+			}
+		}
+
+		if typeIdx < 0 {
+			class.ClassTypes = append(class.ClassTypes, classTypeAttendance{ClassType: fact.ClassType})
+			typeIdx = len(class.ClassTypes) - 1
+		}
+
+		class.ClassTypes[typeIdx].add(fact)
+	}
+
+	for idx := range classes {
+		classes[idx].past = rules.ExcludeExcused(classes[idx].past)
+		classes[idx].upcoming = rules.ExcludeExcused(classes[idx].upcoming)
+	}
+
+	return classes
+}
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/rules"
+)
+
+func (v *APIServerV1) ruleEnvironment(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.ruleEnvironmentGet()
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type ruleEnvironmentGetResponse struct {
+	response
+	Environment rules.Documentation `json:"environment"`
+}
+
+// ruleEnvironmentGet describes the variables, fact fields and helper functions available to advanced rules.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) ruleEnvironmentGet() apiResponse {
+	return ruleEnvironmentGetResponse{
+		newSuccessResponse(),
+		rules.Describe(),
+	}
+}
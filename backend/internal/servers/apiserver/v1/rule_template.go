@@ -0,0 +1,170 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) ruleTemplate(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	templateId, err := to.Int64(r.PathValue("templateId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid rule template id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.ruleTemplateGet(r, templateId)
+	case http.MethodPut:
+		resp = v.ruleTemplatePut(r, templateId)
+	case http.MethodDelete:
+		resp = v.ruleTemplateDelete(r, templateId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type ruleTemplateGetResponse struct {
+	response
+	RuleTemplate database.RuleTemplate        `json:"rule_template"`
+	Classes      []database.RuleTemplateClass `json:"classes"`
+}
+
+// ruleTemplateGet returns a rule template, together with the classes that have a rule instantiated from it.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) ruleTemplateGet(r *http.Request, id int64) apiResponse {
+	template, err := v.db.GetRuleTemplate(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested rule template does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process rule template get database action")
+	}
+
+	classes, err := v.db.GetRuleTemplateClasses(r.Context(), id)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process rule template classes get database action")
+	}
+
+	return ruleTemplateGetResponse{
+		newSuccessResponse(),
+		template,
+		append(make([]database.RuleTemplateClass, 0, len(classes)), classes...),
+	}
+}
+
+type ruleTemplatePutRequest struct {
+	rules.RuleParams
+	// Propagate updates the rules instantiated from the template in the classes coordinated by the user to match the
+	// updated template.
+	Propagate bool `json:"propagate"`
+}
+
+type ruleTemplatePutResponse struct {
+	response
+	RuleTemplate    model.RuleTemplate `json:"rule_template"`
+	PropagatedRules int                `json:"propagated_rules"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) ruleTemplatePut(r *http.Request, id int64) apiResponse {
+	var req ruleTemplatePutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	ruleString, env, err := req.Verify()
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("rule failed validation: %s", err))
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer tx.Rollback()
+
+	template, err := txDb.UpdateRuleTemplate(r.Context(), id, database.UpdateRuleTemplateParams{
+		Title:       req.Title,
+		Description: req.Description,
+		Definition:  req.RuleDefinition,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, qrm.ErrNoRows):
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to update rule template")
+		case database.ErrSQLState(err, database.SQLStateDuplicateKeyOrIndex):
+			return newErrorResponse(http.StatusConflict, "rule template with same title already exists")
+		default:
+			v.logInternalServerError(r, err)
+			return newErrorResponse(http.StatusInternalServerError, "could not process rule template put database action")
+		}
+	}
+
+	var propagated int
+	if req.Propagate {
+		propagated, err = txDb.PropagateRuleTemplate(r.Context(), id, database.PropagateRuleTemplateParams{
+			EditorID:    oauth2.GetAuthContext(r.Context()).User.ID,
+			Title:       req.Title,
+			Description: req.Description,
+			Rule:        ruleString,
+			Env:         env,
+		})
+		if err != nil {
+			if database.ErrSQLState(err, database.SQLStateDuplicateKeyOrIndex) {
+				return newErrorResponse(http.StatusConflict, "a class already has another rule with the same title")
+			}
+
+			v.logInternalServerError(r, err)
+			return newErrorResponse(http.StatusInternalServerError, "could not process rule template propagate database action")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
+	return ruleTemplatePutResponse{
+		newSuccessResponse(),
+		template,
+		propagated,
+	}
+}
+
+type ruleTemplateDeleteResponse struct {
+	response
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) ruleTemplateDelete(r *http.Request, id int64) apiResponse {
+	if err := v.db.DeleteRuleTemplate(r.Context(), id); err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to delete rule template")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process rule template delete database action")
+	}
+
+	return ruleTemplateDeleteResponse{
+		newSuccessResponse(),
+	}
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/rules"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) ruleTemplates(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.ruleTemplatesGet(r)
+	case http.MethodPost:
+		resp = v.ruleTemplatesPost(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type ruleTemplatesGetResponse struct {
+	response
+	RuleTemplates []database.RuleTemplate `json:"rule_templates"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) ruleTemplatesGet(r *http.Request) apiResponse {
+	templates, err := v.db.GetRuleTemplates(r.Context())
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process rule templates get database action")
+	}
+
+	return ruleTemplatesGetResponse{
+		newSuccessResponse(),
+		append(make([]database.RuleTemplate, 0, len(templates)), templates...),
+	}
+}
+
+type ruleTemplatesPostRequest struct {
+	rules.RuleParams
+}
+
+type ruleTemplatesPostResponse struct {
+	response
+	RuleTemplate model.RuleTemplate `json:"rule_template"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) ruleTemplatesPost(r *http.Request) apiResponse {
+	var req ruleTemplatesPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	if _, _, err := req.Verify(); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("rule failed validation: %s", err))
+	}
+
+	template, err := v.db.CreateRuleTemplate(r.Context(), database.CreateRuleTemplateParams{
+		CreatorID:   oauth2.GetAuthContext(r.Context()).User.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		Definition:  req.RuleDefinition,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, qrm.ErrNoRows):
+			return newErrorResponse(http.StatusUnauthorized, "not allowed to create rule template")
+		case database.ErrSQLState(err, database.SQLStateDuplicateKeyOrIndex):
+			return newErrorResponse(http.StatusConflict, "rule template with same title already exists")
+		default:
+			v.logInternalServerError(r, err)
+			return newErrorResponse(http.StatusInternalServerError, "could not process rule templates post database action")
+		}
+	}
+
+	return ruleTemplatesPostResponse{
+		newSuccessResponse(),
+		template,
+	}
+}
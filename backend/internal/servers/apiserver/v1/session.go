@@ -17,6 +17,7 @@ type sessionResponse struct {
 
 type session struct {
 	User              model.User                 `json:"user"`
+	HasSignature      bool                       `json:"has_signature"`
 	ManagementDetails database.ManagementDetails `json:"management_details"`
 }
 
@@ -44,6 +45,13 @@ func (v *APIServerV1) getSession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	hasSignature, err := v.db.HasUserSignature(r.Context(), authContext.User.ID)
+	if err != nil {
+		v.logInternalServerError(r, fmt.Errorf("could not check for session user signature: %w", err))
+		v.writeResponse(w, r, newErrorResponse(http.StatusInternalServerError, "could not get session user signature"))
+		return
+	}
+
 	details, err := v.db.GetManagementDetails(r.Context())
 	if err != nil {
 		v.logInternalServerError(r, fmt.Errorf("could not get user managed class groups: %w", err))
@@ -53,6 +61,6 @@ func (v *APIServerV1) getSession(w http.ResponseWriter, r *http.Request) {
 
 	v.writeResponse(w, r, sessionResponse{
 		newSuccessResponse(),
-		&session{sessionUser, details},
+		&session{sessionUser, hasSignature, details},
 	})
 }
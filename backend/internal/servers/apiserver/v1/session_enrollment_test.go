@@ -29,7 +29,8 @@ func TestAPIServerV1_sessionEnrollmentGet(t *testing.T) {
 			sessionEnrollmentGetResponse{
 				newSuccessResponse(),
 				model.SessionEnrollment{
-					Attended: true,
+					Status: model.AttendanceStatus_Present,
+					Source: model.AttendanceSource_Import,
 				},
 			},
 			http.StatusOK,
@@ -59,7 +60,7 @@ func TestAPIServerV1_sessionEnrollmentGet(t *testing.T) {
 			if tt.withExistingSessionEnrollment {
 				createdEnrollment := tests.StubSessionEnrollment(
 					t, ctx, v1.db,
-					tt.wantResponse.SessionEnrollment.Attended,
+					tt.wantResponse.SessionEnrollment.Status,
 				)
 
 				tt.wantResponse.SessionEnrollment.ID = createdEnrollment.ID
@@ -75,7 +75,8 @@ func TestAPIServerV1_sessionEnrollmentsGet(t *testing.T) {
 				newSuccessResponse(),
 				[]model.SessionEnrollment{
 					{
-						Attended: false,
+						Status: model.AttendanceStatus_Absent,
+						Source: model.AttendanceSource_Import,
 					},
 				},
 			},
@@ -104,7 +105,7 @@ func TestAPIServerV1_sessionEnrollmentsGet(t *testing.T) {
 
 			if tt.withExistingSessionEnrollment {
 				for idx, enrollment := range tt.wantResponse.SessionEnrollments {
-					createdEnrollment := tests.StubSessionEnrollment(t, ctx, v1.db, enrollment.Attended)
+					createdEnrollment := tests.StubSessionEnrollment(t, ctx, v1.db, enrollment.Status)
 					sessionPtr := &tt.wantResponse.SessionEnrollments[idx]
 					sessionPtr.ID = createdEnrollment.ID
 					sessionPtr.SessionID = createdEnrollment.SessionID
@@ -34,6 +34,14 @@ func (v *APIServerV1) signaturePut(r *http.Request, userId string) apiResponse {
 		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse user request body: %s", err))
 	}
 
+	// The user ID was previously accepted as a default signature, so it cannot be used as a signature.
+	switch {
+	case req.Signature == "":
+		return newErrorResponse(http.StatusBadRequest, "signature cannot be empty")
+	case req.Signature == userId:
+		return newErrorResponse(http.StatusBadRequest, "signature cannot be the same as the user id")
+	}
+
 	if err := v.db.UpdateUserSignature(r.Context(), userId, req.Signature); err != nil {
 		v.logInternalServerError(r, err)
 		return newErrorResponse(http.StatusInternalServerError, "could not update user signature")
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 	"github.com/darylhjd/oams/backend/pkg/to"
 	"github.com/go-jet/jet/v2/qrm"
 )
@@ -37,13 +38,13 @@ func (v *APIServerV1) upcomingClassGroupSessionAttendance(w http.ResponseWriter,
 }
 
 type upcomingClassGroupSessionAttendancePatchRequest struct {
-	Attended      bool   `json:"attended"`
-	UserSignature string `json:"user_signature"`
+	Status        model.AttendanceStatus `json:"status"`
+	UserSignature string                 `json:"user_signature"`
 }
 
 type upcomingClassGroupSessionAttendancePatchResponse struct {
 	response
-	Attended bool `json:"attended"`
+	Status model.AttendanceStatus `json:"status"`
 }
 
 // TODO: Implement tests for this endpoint.
@@ -53,15 +54,26 @@ func (v *APIServerV1) upcomingClassGroupSessionAttendancePatch(r *http.Request,
 		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
 	}
 
+	if !isValidAttendanceStatus(req.Status) {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("unknown attendance status %q", req.Status))
+	}
+
 	s, err := v.db.UpdateAttendanceEntry(r.Context(), database.UpdateAttendanceEntryParams{
 		ClassGroupSessionID: sessionId,
 		SessionEnrollmentID: enrollmentId,
-		Attended:            req.Attended,
+		Status:              req.Status,
 		UserSignature:       req.UserSignature,
 	})
 	if err != nil {
-		if errors.Is(err, qrm.ErrNoRows) {
+		switch {
+		case errors.Is(err, qrm.ErrNoRows):
 			return newErrorResponse(http.StatusUnauthorized, "not allowed to take attendance")
+		case errors.Is(err, database.ErrSignatureNotSet):
+			return newErrorResponse(http.StatusConflict, "user must set a signature before attendance can be taken")
+		case errors.Is(err, database.ErrSignatureMismatch):
+			return newErrorResponse(http.StatusUnauthorized, "incorrect user signature")
+		case errors.Is(err, database.ErrSignatureLocked):
+			return newErrorResponse(http.StatusTooManyRequests, "too many failed signature attempts, try again later")
 		}
 
 		v.logInternalServerError(r, err)
@@ -70,6 +82,17 @@ func (v *APIServerV1) upcomingClassGroupSessionAttendancePatch(r *http.Request,
 
 	return upcomingClassGroupSessionAttendancePatchResponse{
 		newSuccessResponse(),
-		s.Attended,
+		s.Status,
+	}
+}
+
+// isValidAttendanceStatus checks that a given attendance status is a known value.
+func isValidAttendanceStatus(status model.AttendanceStatus) bool {
+	switch status {
+	case model.AttendanceStatus_Present, model.AttendanceStatus_Late,
+		model.AttendanceStatus_Absent, model.AttendanceStatus_Excused:
+		return true
+	default:
+		return false
 	}
 }
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+	"go.uber.org/zap"
+)
+
+const (
+	attendanceStreamEvent             = "attendance"
+	attendanceStreamKeepAliveInterval = time.Second * 15
+)
+
+func (v *APIServerV1) upcomingClassGroupSessionAttendanceStream(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	sessionId, err := to.Int64(r.PathValue("sessionId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class group session id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		// Special case for event stream, cannot use v.writeResponse helper.
+		if err := v.upcomingClassGroupSessionAttendanceStreamGet(w, r, sessionId); err != nil {
+			resp = *err
+		} else {
+			return
+		}
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+// upcomingClassGroupSessionAttendanceStreamGet streams attendance changes for an upcoming class group session as
+// Server-Sent Events. Each event contains the updated attendance entry. The stream ends when attendance taking closes.
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) upcomingClassGroupSessionAttendanceStreamGet(w http.ResponseWriter, r *http.Request, sessionId int64) *errorResponse {
+	upcoming, err := v.db.GetUpcomingManagedClassGroupSession(r.Context(), sessionId)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return to.Ptr(newErrorResponse(http.StatusNotFound, "the requested upcoming class group session does not exist"))
+		}
+
+		v.logInternalServerError(r, err)
+		return to.Ptr(newErrorResponse(http.StatusInternalServerError, "could not process upcoming class group session get database action"))
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return to.Ptr(newErrorResponse(http.StatusInternalServerError, "streaming is not supported"))
+	}
+
+	changes, unsubscribe := v.broker.Subscribe(upcoming.ID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(attendanceStreamKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	attendanceClose := time.NewTimer(time.Until(upcoming.AttendanceClosesAt))
+	defer attendanceClose.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-attendanceClose.C:
+			return nil
+		case <-keepAlive.C:
+			if _, err = fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		case entry := <-changes:
+			data, err := json.Marshal(entry)
+			if err != nil {
+				v.logInternalServerError(r, err)
+				continue
+			}
+
+			if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", attendanceStreamEvent, data); err != nil {
+				v.l.Debug(fmt.Sprintf("%s - attendance stream closed", namespace), zap.Int64("session_id", upcoming.ID), zap.Error(err))
+				return nil
+			}
+		}
+
+		flusher.Flush()
+	}
+}
@@ -1,10 +1,13 @@
 package v1
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
 	"github.com/darylhjd/oams/backend/pkg/to"
 	"github.com/go-jet/jet/v2/qrm"
 )
@@ -21,6 +24,8 @@ func (v *APIServerV1) upcomingClassGroupSessionAttendances(w http.ResponseWriter
 	switch r.Method {
 	case http.MethodGet:
 		resp = v.upcomingClassGroupSessionAttendancesGet(r, sessionId)
+	case http.MethodPatch:
+		resp = v.upcomingClassGroupSessionAttendancesPatch(r, sessionId)
 	default:
 		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
 	}
@@ -61,3 +66,85 @@ func (v *APIServerV1) upcomingClassGroupSessionAttendancesGet(r *http.Request, s
 		),
 	}
 }
+
+const (
+	maxBulkAttendanceEntries = 1000
+)
+
+type upcomingClassGroupSessionAttendancesPatchRequest struct {
+	AllStatus         *model.AttendanceStatus              `json:"all_status"`
+	AttendanceEntries []database.BulkAttendanceEntryParams `json:"attendance_entries"`
+}
+
+type upcomingClassGroupSessionAttendancesPatchResponse struct {
+	response
+	Results []database.BulkAttendanceEntryResult `json:"results"`
+}
+
+// upcomingClassGroupSessionAttendancesPatch updates the attendance of many session enrollments at once. If all_status
+// is set, it is applied to every session enrollment that is not in attendance_entries. Signatures are not required.
+func (v *APIServerV1) upcomingClassGroupSessionAttendancesPatch(r *http.Request, sessionId int64) apiResponse {
+	var req upcomingClassGroupSessionAttendancesPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	switch {
+	case req.AllStatus == nil && len(req.AttendanceEntries) == 0:
+		return newErrorResponse(http.StatusBadRequest, "all_status or attendance_entries is required")
+	case len(req.AttendanceEntries) > maxBulkAttendanceEntries:
+		return newErrorResponse(http.StatusRequestEntityTooLarge, fmt.Sprintf("at most %d attendance entries can be updated at once", maxBulkAttendanceEntries))
+	case req.AllStatus != nil && !isValidAttendanceStatus(*req.AllStatus):
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("unknown attendance status %q", *req.AllStatus))
+	}
+
+	seen := make(map[int64]struct{}, len(req.AttendanceEntries))
+	for _, entry := range req.AttendanceEntries {
+		if !isValidAttendanceStatus(entry.Status) {
+			return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("unknown attendance status %q", entry.Status))
+		}
+
+		if _, ok := seen[entry.SessionEnrollmentID]; ok {
+			return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("duplicate session enrollment id %d", entry.SessionEnrollmentID))
+		}
+		seen[entry.SessionEnrollmentID] = struct{}{}
+	}
+
+	txDb, tx, err := v.db.AsTx(r.Context(), nil)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not start database transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = txDb.GetUpcomingManagedClassGroupSession(r.Context(), sessionId); err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested upcoming class group session does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process upcoming class group session get database action")
+	}
+
+	results, err := txDb.BulkUpdateAttendanceEntries(r.Context(), database.BulkUpdateAttendanceEntriesParams{
+		ClassGroupSessionID: sessionId,
+		AllStatus:           req.AllStatus,
+		Entries:             req.AttendanceEntries,
+	})
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not update attendance")
+	}
+
+	if err = tx.Commit(); err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not commit database transaction")
+	}
+
+	return upcomingClassGroupSessionAttendancesPatchResponse{
+		newSuccessResponse(),
+		append(make([]database.BulkAttendanceEntryResult, 0, len(results)), results...),
+	}
+}
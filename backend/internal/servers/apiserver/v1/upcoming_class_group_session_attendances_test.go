@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/tests"
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIServerV1_upcomingClassGroupSessionAttendancesPatch(t *testing.T) {
+	t.Parallel()
+
+	// Each case receives the IDs of two session enrollments in the session being updated and one session enrollment
+	// in another open session.
+	tts := []struct {
+		name           string
+		withRequest    func(first, second, other int64) upcomingClassGroupSessionAttendancesPatchRequest
+		wantStatusCode int
+		wantResults    func(first, second, other int64) []database.BulkAttendanceEntryResult
+		wantStatuses   [3]model.AttendanceStatus
+	}{
+		{
+			"request updating entry",
+			func(first, _, _ int64) upcomingClassGroupSessionAttendancesPatchRequest {
+				return upcomingClassGroupSessionAttendancesPatchRequest{
+					AttendanceEntries: []database.BulkAttendanceEntryParams{
+						{SessionEnrollmentID: first, Status: model.AttendanceStatus_Present},
+					},
+				}
+			},
+			http.StatusOK,
+			func(first, _, _ int64) []database.BulkAttendanceEntryResult {
+				return []database.BulkAttendanceEntryResult{
+					{SessionEnrollmentID: first, Status: model.AttendanceStatus_Present, Updated: true},
+				}
+			},
+			[3]model.AttendanceStatus{
+				model.AttendanceStatus_Present,
+				model.AttendanceStatus_Absent,
+				model.AttendanceStatus_Absent,
+			},
+		},
+		{
+			"request with entry from another session",
+			func(first, _, other int64) upcomingClassGroupSessionAttendancesPatchRequest {
+				return upcomingClassGroupSessionAttendancesPatchRequest{
+					AttendanceEntries: []database.BulkAttendanceEntryParams{
+						{SessionEnrollmentID: other, Status: model.AttendanceStatus_Present},
+						{SessionEnrollmentID: first, Status: model.AttendanceStatus_Late},
+					},
+				}
+			},
+			http.StatusOK,
+			func(first, _, other int64) []database.BulkAttendanceEntryResult {
+				return []database.BulkAttendanceEntryResult{
+					{SessionEnrollmentID: other, Status: model.AttendanceStatus_Present, Updated: false},
+					{SessionEnrollmentID: first, Status: model.AttendanceStatus_Late, Updated: true},
+				}
+			},
+			[3]model.AttendanceStatus{
+				model.AttendanceStatus_Late,
+				model.AttendanceStatus_Absent,
+				model.AttendanceStatus_Absent,
+			},
+		},
+		{
+			"request with entry and all status",
+			func(first, _, _ int64) upcomingClassGroupSessionAttendancesPatchRequest {
+				return upcomingClassGroupSessionAttendancesPatchRequest{
+					AllStatus: to.Ptr(model.AttendanceStatus_Present),
+					AttendanceEntries: []database.BulkAttendanceEntryParams{
+						{SessionEnrollmentID: first, Status: model.AttendanceStatus_Excused},
+					},
+				}
+			},
+			http.StatusOK,
+			func(first, second, _ int64) []database.BulkAttendanceEntryResult {
+				return []database.BulkAttendanceEntryResult{
+					{SessionEnrollmentID: first, Status: model.AttendanceStatus_Excused, Updated: true},
+					{SessionEnrollmentID: second, Status: model.AttendanceStatus_Present, Updated: true},
+				}
+			},
+			[3]model.AttendanceStatus{
+				model.AttendanceStatus_Excused,
+				model.AttendanceStatus_Present,
+				model.AttendanceStatus_Absent,
+			},
+		},
+		{
+			"request with only all status",
+			func(_, _, _ int64) upcomingClassGroupSessionAttendancesPatchRequest {
+				return upcomingClassGroupSessionAttendancesPatchRequest{
+					AllStatus: to.Ptr(model.AttendanceStatus_Late),
+				}
+			},
+			http.StatusOK,
+			func(first, second, _ int64) []database.BulkAttendanceEntryResult {
+				return []database.BulkAttendanceEntryResult{
+					{SessionEnrollmentID: first, Status: model.AttendanceStatus_Late, Updated: true},
+					{SessionEnrollmentID: second, Status: model.AttendanceStatus_Late, Updated: true},
+				}
+			},
+			[3]model.AttendanceStatus{
+				model.AttendanceStatus_Late,
+				model.AttendanceStatus_Late,
+				model.AttendanceStatus_Absent,
+			},
+		},
+		{
+			"request with unknown entry status",
+			func(first, _, _ int64) upcomingClassGroupSessionAttendancesPatchRequest {
+				return upcomingClassGroupSessionAttendancesPatchRequest{
+					AllStatus: to.Ptr(model.AttendanceStatus_Present),
+					AttendanceEntries: []database.BulkAttendanceEntryParams{
+						{SessionEnrollmentID: first, Status: "UNKNOWN"},
+					},
+				}
+			},
+			http.StatusBadRequest,
+			nil,
+			[3]model.AttendanceStatus{
+				model.AttendanceStatus_Absent,
+				model.AttendanceStatus_Absent,
+				model.AttendanceStatus_Absent,
+			},
+		},
+		{
+			"request with duplicate entries",
+			func(first, _, _ int64) upcomingClassGroupSessionAttendancesPatchRequest {
+				return upcomingClassGroupSessionAttendancesPatchRequest{
+					AttendanceEntries: []database.BulkAttendanceEntryParams{
+						{SessionEnrollmentID: first, Status: model.AttendanceStatus_Present},
+						{SessionEnrollmentID: first, Status: model.AttendanceStatus_Late},
+					},
+				}
+			},
+			http.StatusBadRequest,
+			nil,
+			[3]model.AttendanceStatus{
+				model.AttendanceStatus_Absent,
+				model.AttendanceStatus_Absent,
+				model.AttendanceStatus_Absent,
+			},
+		},
+		{
+			"request with no updates",
+			func(_, _, _ int64) upcomingClassGroupSessionAttendancesPatchRequest {
+				return upcomingClassGroupSessionAttendancesPatchRequest{}
+			},
+			http.StatusBadRequest,
+			nil,
+			[3]model.AttendanceStatus{
+				model.AttendanceStatus_Absent,
+				model.AttendanceStatus_Absent,
+				model.AttendanceStatus_Absent,
+			},
+		},
+	}
+
+	for _, tt := range tts {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := assert.New(t)
+			ctx := context.WithValue(context.Background(), oauth2.AuthContextKey, tests.StubAuthContext())
+			id := uuid.NewString()
+
+			v1 := newTestAPIServerV1(t, id)
+			defer tests.TearDown(t, v1.db, id)
+
+			tests.StubUser(t, ctx, v1.db, database.CreateUserParams{
+				ID:   tests.MockAuthenticatorUserID,
+				Role: tests.MockAuthenticatorUserRole,
+			})
+
+			startTime, endTime := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+			first := tests.StubSessionEnrollmentAt(t, ctx, v1.db, startTime, endTime, model.AttendanceStatus_Absent)
+			other := tests.StubSessionEnrollmentAt(t, ctx, v1.db, startTime, endTime, model.AttendanceStatus_Absent)
+
+			user := tests.StubUser(t, ctx, v1.db, database.CreateUserParams{
+				ID:   uuid.NewString(),
+				Role: model.UserRole_User,
+			})
+			second, err := v1.db.CreateSessionEnrollment(ctx, database.CreateSessionEnrollmentParams{
+				SessionID: first.SessionID,
+				UserID:    user.ID,
+				Status:    model.AttendanceStatus_Absent,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body, err := json.Marshal(tt.withRequest(first.ID, second.ID, other.ID))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httpRequestWithAuthContext(
+				httptest.NewRequest(http.MethodPatch, upcomingClassGroupSessionAttendancesUrl, bytes.NewReader(body)),
+				tests.StubAuthContext(),
+			)
+			resp := v1.upcomingClassGroupSessionAttendancesPatch(req, first.SessionID)
+			a.Equal(tt.wantStatusCode, resp.Code())
+
+			if tt.wantResults != nil {
+				actualResp, ok := resp.(upcomingClassGroupSessionAttendancesPatchResponse)
+				a.True(ok)
+				a.ElementsMatch(tt.wantResults(first.ID, second.ID, other.ID), actualResp.Results)
+			}
+
+			for idx, enrollment := range []model.SessionEnrollment{first, second, other} {
+				actualEnrollment, err := v1.db.GetSessionEnrollment(ctx, enrollment.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				a.Equal(tt.wantStatuses[idx], actualEnrollment.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) upcomingClassGroupSessionCheckIn(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	sessionId, err := to.Int64(r.PathValue("sessionId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid class group session id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.upcomingClassGroupSessionCheckInGet(r, sessionId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type upcomingClassGroupSessionCheckInGetResponse struct {
+	response
+	Token     string    `json:"token"`
+	RefreshAt time.Time `json:"refresh_at"`
+}
+
+// upcomingClassGroupSessionCheckInGet returns the current check-in token for an upcoming class group session. The
+// token is meant to be displayed (e.g. as a QR code) for students to scan, and should be refreshed at RefreshAt.
+func (v *APIServerV1) upcomingClassGroupSessionCheckInGet(r *http.Request, sessionId int64) apiResponse {
+	upcoming, err := v.db.GetUpcomingManagedClassGroupSession(r.Context(), sessionId)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested upcoming class group session does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process upcoming class group session get database action")
+	}
+
+	token, refreshAt, err := v.checkInIssuer.Issue(upcoming.ID, time.Now())
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not issue check-in token")
+	}
+
+	return upcomingClassGroupSessionCheckInGetResponse{
+		newSuccessResponse(),
+		token,
+		refreshAt,
+	}
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/darylhjd/oams/backend/pkg/to"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func (v *APIServerV1) userCard(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	cardId, err := to.Int64(r.PathValue("cardId"))
+	if err != nil {
+		v.writeResponse(w, r, newErrorResponse(http.StatusUnprocessableEntity, "invalid user card id"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		resp = v.userCardDelete(r, cardId)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type userCardDeleteResponse struct {
+	response
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) userCardDelete(r *http.Request, cardId int64) apiResponse {
+	if err := v.db.DeleteUserCard(r.Context(), cardId); err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return newErrorResponse(http.StatusNotFound, "the requested user card does not exist")
+		}
+
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not delete user card")
+	}
+
+	return userCardDeleteResponse{
+		newSuccessResponse(),
+	}
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/table"
+)
+
+func (v *APIServerV1) userCards(w http.ResponseWriter, r *http.Request) {
+	var resp apiResponse
+
+	switch r.Method {
+	case http.MethodGet:
+		resp = v.userCardsGet(r)
+	case http.MethodPost:
+		resp = v.userCardsPost(r)
+	default:
+		resp = newErrorResponse(http.StatusMethodNotAllowed, "")
+	}
+
+	v.writeResponse(w, r, resp)
+}
+
+type userCardsGetResponse struct {
+	response
+	UserCards []model.UserCard `json:"user_cards"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) userCardsGet(r *http.Request) apiResponse {
+	params, err := database.DecodeListQueryParams(r.URL.Query(), table.UserCards.AllColumns)
+	if err != nil {
+		return newErrorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	cards, err := v.db.ListUserCards(r.Context(), params)
+	if err != nil {
+		v.logInternalServerError(r, err)
+		return newErrorResponse(http.StatusInternalServerError, "could not process user cards get database action")
+	}
+
+	return userCardsGetResponse{
+		newSuccessResponse(),
+		append(make([]model.UserCard, 0, len(cards)), cards...),
+	}
+}
+
+type userCardsPostResponse struct {
+	response
+	UserCard model.UserCard `json:"user_card"`
+}
+
+// TODO: Implement tests for this endpoint.
+func (v *APIServerV1) userCardsPost(r *http.Request) apiResponse {
+	var req database.CreateUserCardParams
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newErrorResponse(http.StatusBadRequest, fmt.Sprintf("could not parse request body: %s", err))
+	}
+
+	req.CardUID = normalizeCardUid(req.CardUID)
+	if req.CardUID == "" {
+		return newErrorResponse(http.StatusBadRequest, "a card uid is required")
+	}
+
+	card, err := v.db.CreateUserCard(r.Context(), req)
+	if err != nil {
+		switch {
+		case database.ErrSQLState(err, database.SQLStateForeignKeyViolation):
+			return newErrorResponse(http.StatusBadRequest, "user does not exist")
+		case database.ErrSQLState(err, database.SQLStateDuplicateKeyOrIndex):
+			return newErrorResponse(http.StatusConflict, "card is already mapped to a user")
+		default:
+			v.logInternalServerError(r, err)
+			return newErrorResponse(http.StatusInternalServerError, "could not process user cards post database action")
+		}
+	}
+
+	return userCardsPostResponse{
+		newSuccessResponse(),
+		card,
+	}
+}
+
+// normalizeCardUid normalizes a card UID so that the same card is matched regardless of how a reader formats it.
+func normalizeCardUid(uid string) string {
+	return strings.ToUpper(strings.TrimSpace(uid))
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/gorilla/schema"
 	"go.uber.org/zap"
 
+	"github.com/darylhjd/oams/backend/internal/checkin"
 	"github.com/darylhjd/oams/backend/internal/database"
 	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/stream"
 )
 
 const namespace = "apiserver/v1"
@@ -19,39 +21,70 @@ const (
 )
 
 const (
-	baseUrl                                 = "/"
-	pingUrl                                 = "/ping"
-	loginUrl                                = "/login"
-	msLoginCallbackUrl                      = "/ms-login-callback"
-	logoutUrl                               = "/logout"
-	sessionUrl                              = "/session"
-	signatureUrl                            = "/signature/{userId}"
-	batchUrl                                = "/batch"
-	usersUrl                                = "/users"
-	userUrl                                 = "/users/{userId}"
-	classesUrl                              = "/classes"
-	classUrl                                = "/classes/{classId}"
-	classAttendanceRulesUrl                 = "/class-attendance-rules"
-	classGroupManagersUrl                   = "/class-group-managers"
-	classGroupManagerUrl                    = "/class-group-managers/{managerId}"
-	classGroupsUrl                          = "/class-groups"
-	classGroupUrl                           = "/class-groups/{groupId}"
-	classGroupSessionsUrl                   = "/class-group-sessions"
-	classGroupSessionUrl                    = "/class-group-sessions/{sessionId}"
-	sessionEnrollmentsUrl                   = "/session-enrollments"
-	sessionEnrollmentUrl                    = "/session-enrollments/{enrollmentId}"
-	upcomingClassGroupSessionsUrl           = "/upcoming-class-group-sessions"
-	upcomingClassGroupSessionAttendancesUrl = "/upcoming-class-group-sessions/{sessionId}/attendances"
-	upcomingClassGroupSessionAttendanceUrl  = "/upcoming-class-group-sessions/{sessionId}/attendances/{enrollmentId}"
-	coordinatingClassesUrl                  = "/coordinating-classes"
-	coordinatingClassUrl                    = "/coordinating-classes/{classId}"
-	coordinatingClassRulesUrl               = "/coordinating-classes/{classId}/rules"
-	coordinatingClassRuleUrl                = "/coordinating-classes/{classId}/rules/{ruleId}"
-	coordinatingClassReportUrl              = "/coordinating-classes/{classId}/report"
-	coordinatingClassDashboardUrl           = "/coordinating-classes/{classId}/dashboard"
-	coordinatingClassSchedulesUrl           = "/coordinating-classes/{classId}/schedule"
-	coordinatingClassScheduleUrl            = "/coordinating-classes/{classId}/schedule/{sessionId}"
-	dataExportUrl                           = "/data-export"
+	baseUrl                                      = "/"
+	pingUrl                                      = "/ping"
+	loginUrl                                     = "/login"
+	msLoginCallbackUrl                           = "/ms-login-callback"
+	logoutUrl                                    = "/logout"
+	sessionUrl                                   = "/session"
+	meAttendanceUrl                              = "/me/attendance"
+	signatureUrl                                 = "/signature/{userId}"
+	batchUrl                                     = "/batch"
+	usersUrl                                     = "/users"
+	userUrl                                      = "/users/{userId}"
+	userCardsUrl                                 = "/user-cards"
+	userCardUrl                                  = "/user-cards/{cardId}"
+	classesUrl                                   = "/classes"
+	classUrl                                     = "/classes/{classId}"
+	classAttendanceRulesUrl                      = "/class-attendance-rules"
+	classGroupManagersUrl                        = "/class-group-managers"
+	classGroupManagerUrl                         = "/class-group-managers/{managerId}"
+	classGroupsUrl                               = "/class-groups"
+	classGroupUrl                                = "/class-groups/{groupId}"
+	classGroupSessionsUrl                        = "/class-group-sessions"
+	classGroupSessionUrl                         = "/class-group-sessions/{sessionId}"
+	sessionEnrollmentsUrl                        = "/session-enrollments"
+	sessionEnrollmentUrl                         = "/session-enrollments/{enrollmentId}"
+	upcomingClassGroupSessionsUrl                = "/upcoming-class-group-sessions"
+	upcomingClassGroupSessionAttendancesUrl      = "/upcoming-class-group-sessions/{sessionId}/attendances"
+	upcomingClassGroupSessionAttendanceUrl       = "/upcoming-class-group-sessions/{sessionId}/attendances/{enrollmentId}"
+	upcomingClassGroupSessionAttendanceStreamUrl = "/upcoming-class-group-sessions/{sessionId}/attendance-stream"
+	upcomingClassGroupSessionCheckInUrl          = "/upcoming-class-group-sessions/{sessionId}/check-in"
+	checkInUrl                                   = "/check-in"
+	cardTapsUrl                                  = "/card-taps"
+	attendanceAmendmentsUrl                      = "/attendance-amendments"
+	excusalsUrl                                  = "/excusals"
+	excusalUrl                                   = "/excusals/{excusalId}"
+	excusalDocumentUrl                           = "/excusals/{excusalId}/document"
+	coordinatingClassesUrl                       = "/coordinating-classes"
+	coordinatingClassUrl                         = "/coordinating-classes/{classId}"
+	coordinatingClassGroupsUrl                   = "/coordinating-classes/{classId}/groups"
+	coordinatingClassGroupUrl                    = "/coordinating-classes/{classId}/groups/{groupId}"
+	coordinatingClassRulesUrl                    = "/coordinating-classes/{classId}/rules"
+	coordinatingClassRuleUrl                     = "/coordinating-classes/{classId}/rules/{ruleId}"
+	coordinatingClassRuleVersionsUrl             = "/coordinating-classes/{classId}/rules/{ruleId}/versions"
+	coordinatingClassRulesDryRunUrl              = "/coordinating-classes/{classId}/rules/dry-run"
+	coordinatingClassRulesFromTemplateUrl        = "/coordinating-classes/{classId}/rules/from-template"
+	coordinatingClassRuleEvaluationsUrl          = "/coordinating-classes/{classId}/rule-evaluations"
+	coordinatingClassReportUrl                   = "/coordinating-classes/{classId}/report"
+	coordinatingClassDashboardUrl                = "/coordinating-classes/{classId}/dashboard"
+	coordinatingClassSchedulesUrl                = "/coordinating-classes/{classId}/schedule"
+	coordinatingClassScheduleUrl                 = "/coordinating-classes/{classId}/schedule/{sessionId}"
+	coordinatingClassAttendanceAmendmentsUrl     = "/coordinating-classes/{classId}/attendance-amendments"
+	coordinatingClassAttendanceAmendmentUrl      = "/coordinating-classes/{classId}/attendance-amendments/{amendmentId}"
+	coordinatingClassExcusalsUrl                 = "/coordinating-classes/{classId}/excusals"
+	coordinatingClassExcusalUrl                  = "/coordinating-classes/{classId}/excusals/{excusalId}"
+	coordinatingClassNotificationTemplatesUrl    = "/coordinating-classes/{classId}/notification-templates"
+	coordinatingClassNotificationPreviewUrl      = "/coordinating-classes/{classId}/notification-templates/preview"
+	ruleTemplatesUrl                             = "/rule-templates"
+	ruleTemplateUrl                              = "/rule-templates/{templateId}"
+	ruleEnvironmentUrl                           = "/rule-environment"
+	globalRulesUrl                               = "/global-rules"
+	globalRuleUrl                                = "/global-rules/{ruleId}"
+	interventionPreviewUrl                       = "/intervention/preview"
+	interventionRunsUrl                          = "/intervention-runs"
+	interventionRunUrl                           = "/intervention-runs/{runId}"
+	dataExportUrl                                = "/data-export"
 )
 
 type APIServerV1 struct {
@@ -61,12 +94,14 @@ type APIServerV1 struct {
 
 	decoder *schema.Decoder
 
-	auth oauth2.AuthProvider
+	auth          oauth2.AuthProvider
+	checkInIssuer *checkin.Issuer
+	broker        *stream.Broker
 }
 
 // New creates a new APIServerV1. This is a sub-router and should not be used as a base router.
-func New(l *zap.Logger, db *database.DB, auth oauth2.AuthProvider) *APIServerV1 {
-	server := APIServerV1{l, db, http.NewServeMux(), schema.NewDecoder(), auth}
+func New(l *zap.Logger, db *database.DB, auth oauth2.AuthProvider, checkInIssuer *checkin.Issuer, broker *stream.Broker) *APIServerV1 {
+	server := APIServerV1{l, db, http.NewServeMux(), schema.NewDecoder(), auth, checkInIssuer, broker}
 	server.registerHandlers()
 
 	return &server
@@ -82,6 +117,14 @@ func (v *APIServerV1) registerHandlers() {
 
 	v.mux.HandleFunc(sessionUrl, v.session)
 
+	v.mux.HandleFunc(meAttendanceUrl, v.enforceAccess(
+		v.meAttendance,
+		map[string]permission{
+			http.MethodGet: MyAttendanceRead,
+		},
+		[]string{},
+	))
+
 	v.mux.HandleFunc(signatureUrl, v.enforceAccess(
 		v.signature,
 		map[string]permission{
@@ -116,6 +159,23 @@ func (v *APIServerV1) registerHandlers() {
 		[]string{},
 	))
 
+	v.mux.HandleFunc(userCardsUrl, v.enforceAccess(
+		v.userCards,
+		map[string]permission{
+			http.MethodGet:  UserCardRead,
+			http.MethodPost: UserCardCreate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(userCardUrl, v.enforceAccess(
+		v.userCard,
+		map[string]permission{
+			http.MethodDelete: UserCardDelete,
+		},
+		[]string{},
+	))
+
 	v.mux.HandleFunc(classesUrl, v.enforceAccess(
 		v.classes,
 		map[string]permission{
@@ -219,7 +279,8 @@ func (v *APIServerV1) registerHandlers() {
 	v.mux.HandleFunc(upcomingClassGroupSessionAttendancesUrl, v.enforceAccess(
 		v.upcomingClassGroupSessionAttendances,
 		map[string]permission{
-			http.MethodGet: UpcomingClassGroupSessionAttendanceRead,
+			http.MethodGet:   UpcomingClassGroupSessionAttendanceRead,
+			http.MethodPatch: UpcomingClassGroupSessionAttendanceUpdate,
 		},
 		[]string{roleAttendanceTaker},
 	))
@@ -232,6 +293,72 @@ func (v *APIServerV1) registerHandlers() {
 		[]string{roleAttendanceTaker},
 	))
 
+	v.mux.HandleFunc(upcomingClassGroupSessionAttendanceStreamUrl, v.enforceAccess(
+		v.upcomingClassGroupSessionAttendanceStream,
+		map[string]permission{
+			http.MethodGet: UpcomingClassGroupSessionAttendanceRead,
+		},
+		[]string{roleAttendanceTaker},
+	))
+
+	v.mux.HandleFunc(upcomingClassGroupSessionCheckInUrl, v.enforceAccess(
+		v.upcomingClassGroupSessionCheckIn,
+		map[string]permission{
+			http.MethodGet: UpcomingClassGroupSessionCheckInRead,
+		},
+		[]string{roleAttendanceTaker},
+	))
+
+	v.mux.HandleFunc(checkInUrl, v.enforceAccess(
+		v.checkIn,
+		map[string]permission{
+			http.MethodPost: CheckInPost,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(cardTapsUrl, v.enforceAccess(
+		v.cardTaps,
+		map[string]permission{
+			http.MethodPost: CardTapPost,
+		},
+		[]string{roleAttendanceTaker},
+	))
+
+	v.mux.HandleFunc(attendanceAmendmentsUrl, v.enforceAccess(
+		v.attendanceAmendments,
+		map[string]permission{
+			http.MethodGet:  AttendanceAmendmentRead,
+			http.MethodPost: AttendanceAmendmentCreate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(excusalsUrl, v.enforceAccess(
+		v.excusals,
+		map[string]permission{
+			http.MethodGet:  ExcusalRead,
+			http.MethodPost: ExcusalCreate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(excusalUrl, v.enforceAccess(
+		v.excusal,
+		map[string]permission{
+			http.MethodGet: ExcusalRead,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(excusalDocumentUrl, v.enforceAccess(
+		v.excusalDocument,
+		map[string]permission{
+			http.MethodGet: ExcusalRead,
+		},
+		[]string{},
+	))
+
 	v.mux.HandleFunc(coordinatingClassesUrl, v.enforceAccess(
 		v.coordinatingClasses,
 		map[string]permission{
@@ -243,7 +370,24 @@ func (v *APIServerV1) registerHandlers() {
 	v.mux.HandleFunc(coordinatingClassUrl, v.enforceAccess(
 		v.coordinatingClass,
 		map[string]permission{
-			http.MethodGet: CoordinatingClassRead,
+			http.MethodGet:   CoordinatingClassRead,
+			http.MethodPatch: CoordinatingClassUpdate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassGroupsUrl, v.enforceAccess(
+		v.coordinatingClassGroups,
+		map[string]permission{
+			http.MethodGet: CoordinatingClassGroupRead,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassGroupUrl, v.enforceAccess(
+		v.coordinatingClassGroup,
+		map[string]permission{
+			http.MethodPatch: CoordinatingClassGroupUpdate,
 		},
 		[]string{},
 	))
@@ -266,6 +410,38 @@ func (v *APIServerV1) registerHandlers() {
 		[]string{},
 	))
 
+	v.mux.HandleFunc(coordinatingClassRuleVersionsUrl, v.enforceAccess(
+		v.coordinatingClassRuleVersions,
+		map[string]permission{
+			http.MethodGet: CoordinatingClassRuleRead,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassRulesDryRunUrl, v.enforceAccess(
+		v.coordinatingClassRulesDryRun,
+		map[string]permission{
+			http.MethodPost: CoordinatingClassRuleDryRun,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassRulesFromTemplateUrl, v.enforceAccess(
+		v.coordinatingClassRulesFromTemplate,
+		map[string]permission{
+			http.MethodPost: CoordinatingClassRuleCreate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassRuleEvaluationsUrl, v.enforceAccess(
+		v.coordinatingClassRuleEvaluations,
+		map[string]permission{
+			http.MethodGet: CoordinatingClassRuleEvaluationRead,
+		},
+		[]string{},
+	))
+
 	v.mux.HandleFunc(coordinatingClassReportUrl, v.enforceAccess(
 		v.coordinatingClassReport,
 		map[string]permission{
@@ -298,6 +474,126 @@ func (v *APIServerV1) registerHandlers() {
 		[]string{},
 	))
 
+	v.mux.HandleFunc(coordinatingClassAttendanceAmendmentsUrl, v.enforceAccess(
+		v.coordinatingClassAttendanceAmendments,
+		map[string]permission{
+			http.MethodGet: CoordinatingClassAttendanceAmendmentRead,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassAttendanceAmendmentUrl, v.enforceAccess(
+		v.coordinatingClassAttendanceAmendment,
+		map[string]permission{
+			http.MethodPatch: CoordinatingClassAttendanceAmendmentUpdate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassExcusalsUrl, v.enforceAccess(
+		v.coordinatingClassExcusals,
+		map[string]permission{
+			http.MethodGet: CoordinatingClassExcusalRead,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassExcusalUrl, v.enforceAccess(
+		v.coordinatingClassExcusal,
+		map[string]permission{
+			http.MethodPatch: CoordinatingClassExcusalUpdate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassNotificationTemplatesUrl, v.enforceAccess(
+		v.coordinatingClassNotificationTemplates,
+		map[string]permission{
+			http.MethodGet:    CoordinatingClassNotificationTemplateRead,
+			http.MethodPut:    CoordinatingClassNotificationTemplateUpdate,
+			http.MethodDelete: CoordinatingClassNotificationTemplateUpdate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(coordinatingClassNotificationPreviewUrl, v.enforceAccess(
+		v.coordinatingClassNotificationPreview,
+		map[string]permission{
+			http.MethodPost: CoordinatingClassNotificationTemplatePreview,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(ruleTemplatesUrl, v.enforceAccess(
+		v.ruleTemplates,
+		map[string]permission{
+			http.MethodGet:  RuleTemplateRead,
+			http.MethodPost: RuleTemplateCreate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(ruleTemplateUrl, v.enforceAccess(
+		v.ruleTemplate,
+		map[string]permission{
+			http.MethodGet:    RuleTemplateRead,
+			http.MethodPut:    RuleTemplateUpdate,
+			http.MethodDelete: RuleTemplateDelete,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(ruleEnvironmentUrl, v.enforceAccess(
+		v.ruleEnvironment,
+		map[string]permission{
+			http.MethodGet: RuleEnvironmentRead,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(globalRulesUrl, v.enforceAccess(
+		v.globalRules,
+		map[string]permission{
+			http.MethodGet:  GlobalRuleRead,
+			http.MethodPost: GlobalRuleCreate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(globalRuleUrl, v.enforceAccess(
+		v.globalRule,
+		map[string]permission{
+			http.MethodPatch:  GlobalRuleUpdate,
+			http.MethodDelete: GlobalRuleDelete,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(interventionPreviewUrl, v.enforceAccess(
+		v.interventionPreview,
+		map[string]permission{
+			http.MethodGet: InterventionPreviewRead,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(interventionRunsUrl, v.enforceAccess(
+		v.interventionRuns,
+		map[string]permission{
+			http.MethodGet:  InterventionRunRead,
+			http.MethodPost: InterventionRunCreate,
+		},
+		[]string{},
+	))
+
+	v.mux.HandleFunc(interventionRunUrl, v.enforceAccess(
+		v.interventionRun,
+		map[string]permission{
+			http.MethodGet: InterventionRunRead,
+		},
+		[]string{},
+	))
+
 	v.mux.HandleFunc(dataExportUrl, v.enforceAccess(
 		v.dataExport,
 		map[string]permission{
@@ -14,12 +14,18 @@ type permission int
 const (
 	SignaturePut permission = iota
 
+	MyAttendanceRead
+
 	BatchPost
 	BatchPut
 
 	UserRead
 	UserUpdate
 
+	UserCardCreate
+	UserCardRead
+	UserCardDelete
+
 	ClassRead
 
 	ClassAttendanceRulesRead
@@ -41,12 +47,30 @@ const (
 	UpcomingClassGroupSessionAttendanceRead
 	UpcomingClassGroupSessionAttendanceUpdate
 
+	UpcomingClassGroupSessionCheckInRead
+
+	CheckInPost
+
+	CardTapPost
+
+	AttendanceAmendmentCreate
+	AttendanceAmendmentRead
+
+	ExcusalCreate
+	ExcusalRead
+
 	CoordinatingClassRead
+	CoordinatingClassUpdate
+
+	CoordinatingClassGroupRead
+	CoordinatingClassGroupUpdate
 
 	CoordinatingClassRuleCreate
 	CoordinatingClassRuleRead
 	CoordinatingClassRuleUpdate
 	CoordinatingClassRuleDelete
+	CoordinatingClassRuleDryRun
+	CoordinatingClassRuleEvaluationRead
 
 	CoordinatingClassReportRead
 
@@ -55,6 +79,33 @@ const (
 	CoordinatingClassScheduleRead
 	CoordinatingClassScheduleUpdate
 
+	CoordinatingClassAttendanceAmendmentRead
+	CoordinatingClassAttendanceAmendmentUpdate
+
+	CoordinatingClassExcusalRead
+	CoordinatingClassExcusalUpdate
+
+	CoordinatingClassNotificationTemplateRead
+	CoordinatingClassNotificationTemplateUpdate
+	CoordinatingClassNotificationTemplatePreview
+
+	RuleTemplateCreate
+	RuleTemplateRead
+	RuleTemplateUpdate
+	RuleTemplateDelete
+
+	RuleEnvironmentRead
+
+	GlobalRuleCreate
+	GlobalRuleRead
+	GlobalRuleUpdate
+	GlobalRuleDelete
+
+	InterventionPreviewRead
+
+	InterventionRunCreate
+	InterventionRunRead
+
 	DataExportRead
 )
 
@@ -68,6 +119,8 @@ var rolePermissionMapping = map[model.UserRole]permissionMap{
 var userRolePermissions = permissionMap{
 	SignaturePut: {},
 
+	MyAttendanceRead: {},
+
 	UserRead:   {},
 	UserUpdate: {},
 
@@ -84,12 +137,28 @@ var userRolePermissions = permissionMap{
 	UpcomingClassGroupSessionAttendanceRead:   {},
 	UpcomingClassGroupSessionAttendanceUpdate: {},
 
-	CoordinatingClassRead: {},
+	UpcomingClassGroupSessionCheckInRead: {},
+
+	CheckInPost: {},
+
+	AttendanceAmendmentCreate: {},
+	AttendanceAmendmentRead:   {},
+
+	ExcusalCreate: {},
+	ExcusalRead:   {},
+
+	CoordinatingClassRead:   {},
+	CoordinatingClassUpdate: {},
+
+	CoordinatingClassGroupRead:   {},
+	CoordinatingClassGroupUpdate: {},
 
-	CoordinatingClassRuleCreate: {},
-	CoordinatingClassRuleRead:   {},
-	CoordinatingClassRuleUpdate: {},
-	CoordinatingClassRuleDelete: {},
+	CoordinatingClassRuleCreate:         {},
+	CoordinatingClassRuleRead:           {},
+	CoordinatingClassRuleUpdate:         {},
+	CoordinatingClassRuleDelete:         {},
+	CoordinatingClassRuleDryRun:         {},
+	CoordinatingClassRuleEvaluationRead: {},
 
 	CoordinatingClassDashboardRead: {},
 
@@ -97,17 +166,40 @@ var userRolePermissions = permissionMap{
 
 	CoordinatingClassScheduleRead:   {},
 	CoordinatingClassScheduleUpdate: {},
+
+	CoordinatingClassAttendanceAmendmentRead:   {},
+	CoordinatingClassAttendanceAmendmentUpdate: {},
+
+	CoordinatingClassExcusalRead:   {},
+	CoordinatingClassExcusalUpdate: {},
+
+	CoordinatingClassNotificationTemplateRead:    {},
+	CoordinatingClassNotificationTemplateUpdate:  {},
+	CoordinatingClassNotificationTemplatePreview: {},
+
+	RuleTemplateCreate: {},
+	RuleTemplateRead:   {},
+	RuleTemplateUpdate: {},
+	RuleTemplateDelete: {},
+
+	RuleEnvironmentRead: {},
 }
 
 var systemAdminRolePermissions = permissionMap{
 	SignaturePut: {},
 
+	MyAttendanceRead: {},
+
 	BatchPost: {},
 	BatchPut:  {},
 
 	UserRead:   {},
 	UserUpdate: {},
 
+	UserCardCreate: {},
+	UserCardRead:   {},
+	UserCardDelete: {},
+
 	ClassRead: {},
 
 	ClassAttendanceRulesRead: {},
@@ -129,12 +221,30 @@ var systemAdminRolePermissions = permissionMap{
 	UpcomingClassGroupSessionAttendanceRead:   {},
 	UpcomingClassGroupSessionAttendanceUpdate: {},
 
-	CoordinatingClassRead: {},
+	UpcomingClassGroupSessionCheckInRead: {},
+
+	CheckInPost: {},
+
+	CardTapPost: {},
+
+	AttendanceAmendmentCreate: {},
+	AttendanceAmendmentRead:   {},
+
+	ExcusalCreate: {},
+	ExcusalRead:   {},
 
-	CoordinatingClassRuleCreate: {},
-	CoordinatingClassRuleRead:   {},
-	CoordinatingClassRuleUpdate: {},
-	CoordinatingClassRuleDelete: {},
+	CoordinatingClassRead:   {},
+	CoordinatingClassUpdate: {},
+
+	CoordinatingClassGroupRead:   {},
+	CoordinatingClassGroupUpdate: {},
+
+	CoordinatingClassRuleCreate:         {},
+	CoordinatingClassRuleRead:           {},
+	CoordinatingClassRuleUpdate:         {},
+	CoordinatingClassRuleDelete:         {},
+	CoordinatingClassRuleDryRun:         {},
+	CoordinatingClassRuleEvaluationRead: {},
 
 	CoordinatingClassReportRead: {},
 
@@ -143,6 +253,33 @@ var systemAdminRolePermissions = permissionMap{
 	CoordinatingClassScheduleRead:   {},
 	CoordinatingClassScheduleUpdate: {},
 
+	CoordinatingClassAttendanceAmendmentRead:   {},
+	CoordinatingClassAttendanceAmendmentUpdate: {},
+
+	CoordinatingClassExcusalRead:   {},
+	CoordinatingClassExcusalUpdate: {},
+
+	CoordinatingClassNotificationTemplateRead:    {},
+	CoordinatingClassNotificationTemplateUpdate:  {},
+	CoordinatingClassNotificationTemplatePreview: {},
+
+	RuleTemplateCreate: {},
+	RuleTemplateRead:   {},
+	RuleTemplateUpdate: {},
+	RuleTemplateDelete: {},
+
+	RuleEnvironmentRead: {},
+
+	GlobalRuleCreate: {},
+	GlobalRuleRead:   {},
+	GlobalRuleUpdate: {},
+	GlobalRuleDelete: {},
+
+	InterventionPreviewRead: {},
+
+	InterventionRunCreate: {},
+	InterventionRunRead:   {},
+
 	DataExportRead: {},
 }
 
@@ -5,7 +5,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/darylhjd/oams/backend/internal/checkin"
 	"github.com/darylhjd/oams/backend/internal/oauth2"
+	"github.com/darylhjd/oams/backend/internal/stream"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/darylhjd/oams/backend/internal/tests"
@@ -15,7 +18,7 @@ func newTestAPIServerV1(t *testing.T, dbId string) *APIServerV1 {
 	t.Helper()
 
 	testDb := tests.SetUp(t, dbId)
-	return New(zap.NewNop(), testDb, tests.NewMockAzureAuthenticator())
+	return New(zap.NewNop(), testDb, tests.NewMockAzureAuthenticator(), checkin.NewIssuer([]byte(uuid.NewString())), stream.NewBroker(zap.NewNop()))
 }
 
 func httpRequestWithAuthContext(r *http.Request, authContext oauth2.AuthContext) *http.Request {
@@ -0,0 +1,104 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+)
+
+const (
+	Namespace = "stream"
+)
+
+const (
+	// subscriberBufferSize is the number of changes that can be queued for a subscriber. Changes are dropped for
+	// subscribers that fall too far behind.
+	subscriberBufferSize = 64
+
+	// listenRetryInterval is how long to wait before listening again after the database connection is lost.
+	listenRetryInterval = time.Second * 5
+)
+
+// Broker relays attendance changes to subscribers of a class group session. As changes are received from the
+// database, subscribers on every API server instance are kept in sync.
+type Broker struct {
+	l *zap.Logger
+
+	mu          sync.Mutex
+	subscribers map[int64]map[chan database.AttendanceEntry]struct{}
+}
+
+// NewBroker creates a new Broker. Use Listen to start receiving changes from the database.
+func NewBroker(l *zap.Logger) *Broker {
+	return &Broker{
+		l:           l,
+		subscribers: map[int64]map[chan database.AttendanceEntry]struct{}{},
+	}
+}
+
+// Subscribe to attendance changes for a class group session. The returned function must be called to unsubscribe once
+// the caller is no longer interested in changes.
+func (b *Broker) Subscribe(sessionId int64) (<-chan database.AttendanceEntry, func()) {
+	ch := make(chan database.AttendanceEntry, subscriberBufferSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sessionId]; !ok {
+		b.subscribers[sessionId] = map[chan database.AttendanceEntry]struct{}{}
+	}
+	b.subscribers[sessionId][ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.subscribers[sessionId], ch)
+		if len(b.subscribers[sessionId]) == 0 {
+			delete(b.subscribers, sessionId)
+		}
+	}
+}
+
+// Publish an attendance change to all subscribers of its class group session.
+func (b *Broker) Publish(entry database.AttendanceEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[entry.SessionID] {
+		select {
+		case ch <- entry:
+		default:
+			b.l.Warn(fmt.Sprintf("%s - dropped attendance change for slow subscriber", Namespace),
+				zap.Int64("session_id", entry.SessionID),
+				zap.Int64("enrollment_id", entry.ID),
+			)
+		}
+	}
+}
+
+// Listen publishes attendance changes from the database until the context is cancelled. If the database connection is
+// lost, the broker will listen again after some time.
+func (b *Broker) Listen(ctx context.Context, db *database.DB) {
+	for {
+		err := db.ListenSessionEnrollmentChanges(ctx, b.Publish, func(err error) {
+			b.l.Warn(fmt.Sprintf("%s - skipped attendance change", Namespace), zap.Error(err))
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		b.l.Error(fmt.Sprintf("%s - stopped listening for attendance changes", Namespace), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/darylhjd/oams/backend/internal/database"
+	"github.com/darylhjd/oams/backend/internal/database/gen/postgres/public/model"
+)
+
+func TestBroker(t *testing.T) {
+	tts := []struct {
+		name            string
+		subscribeTo     int64
+		publishTo       int64
+		wantDelivered   bool
+		withUnsubscribe bool
+	}{
+		{
+			"publish to subscribed session",
+			1,
+			1,
+			true,
+			false,
+		},
+		{
+			"publish to other session",
+			1,
+			2,
+			false,
+			false,
+		},
+		{
+			"publish after unsubscribe",
+			1,
+			1,
+			false,
+			true,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			broker := NewBroker(zap.NewNop())
+
+			ch, unsubscribe := broker.Subscribe(tt.subscribeTo)
+			if tt.withUnsubscribe {
+				unsubscribe()
+			} else {
+				defer unsubscribe()
+			}
+
+			entry := database.AttendanceEntry{
+				ID:        1,
+				SessionID: tt.publishTo,
+				Status:    model.AttendanceStatus_Present,
+			}
+			broker.Publish(entry)
+
+			select {
+			case got := <-ch:
+				a.True(tt.wantDelivered)
+				a.Equal(entry, got)
+			default:
+				a.False(tt.wantDelivered)
+			}
+		})
+	}
+}
+
+func TestBroker_SlowSubscriber(t *testing.T) {
+	a := assert.New(t)
+	broker := NewBroker(zap.NewNop())
+
+	ch, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBufferSize+1; i++ {
+		broker.Publish(database.AttendanceEntry{ID: int64(i), SessionID: 1})
+	}
+
+	a.Len(ch, subscriberBufferSize)
+}
@@ -100,7 +100,15 @@ func StubClassGroupSession(t *testing.T, ctx context.Context, db *database.DB, s
 }
 
 // StubSessionEnrollment inserts a mock class group session, user, and corresponding session enrollment into the database.
-func StubSessionEnrollment(t *testing.T, ctx context.Context, db *database.DB, attended bool) model.SessionEnrollment {
+func StubSessionEnrollment(t *testing.T, ctx context.Context, db *database.DB, status model.AttendanceStatus) model.SessionEnrollment {
+	t.Helper()
+
+	return StubSessionEnrollmentAt(t, ctx, db, time.UnixMicro(1), time.UnixMicro(2), status)
+}
+
+// StubSessionEnrollmentAt inserts a mock class group session with the given times, user, and corresponding session
+// enrollment into the database.
+func StubSessionEnrollmentAt(t *testing.T, ctx context.Context, db *database.DB, startTime, endTime time.Time, status model.AttendanceStatus) model.SessionEnrollment {
 	t.Helper()
 
 	user := StubUser(t, ctx, db, database.CreateUserParams{
@@ -109,15 +117,15 @@ func StubSessionEnrollment(t *testing.T, ctx context.Context, db *database.DB, a
 	})
 
 	session := StubClassGroupSession(t, ctx, db,
-		time.UnixMicro(1),
-		time.UnixMicro(2),
+		startTime,
+		endTime,
 		uuid.NewString(),
 	)
 
 	enrollment, err := db.CreateSessionEnrollment(ctx, database.CreateSessionEnrollmentParams{
 		SessionID: session.ID,
 		UserID:    user.ID,
-		Attended:  attended,
+		Status:    status,
 	})
 	if err != nil {
 		t.Fatal(err)
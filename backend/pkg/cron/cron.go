@@ -0,0 +1,182 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears is how far ahead Next searches for a matching time. Schedules that only match dates that do not
+// exist, such as the 31st of February, never match.
+const maxSearchYears = 5
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = [...]field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a parsed cron expression with the standard five fields: minute, hour, day of month, month and day of
+// week. Each field accepts *, a value, a range such as 1-5, a step such as */15 or 1-30/2, and comma separated lists of
+// these. Both 0 and 7 are Sunday. As in cron, if both the day of month and the day of week are restricted, a time
+// matches if either of them matches.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	domRestricted, dowRestricted bool
+}
+
+// Parse parses a cron expression.
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("expected %d fields in cron expression %q, got %d", len(fields), expr, len(parts))
+	}
+
+	var bits [len(fields)]uint64
+	for idx, part := range parts {
+		b, err := parseField(part, fields[idx])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in cron expression %q: %w", fields[idx].name, expr, err)
+		}
+
+		bits[idx] = b
+	}
+
+	// Sunday can be given as 0 or 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &Schedule{
+		minute:        bits[0],
+		hour:          bits[1],
+		dom:           bits[2],
+		month:         bits[3],
+		dow:           bits[4],
+		domRestricted: parts[2] != "*",
+		dowRestricted: parts[4] != "*",
+	}, nil
+}
+
+// Next returns the earliest time after t that matches the schedule, in the location of t. The zero time is returned if
+// no time matches.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom, dow := has(s.dom, t.Day()), has(s.dow, int(t.Weekday()))
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+
+	return dom && dow
+}
+
+func has(bits uint64, n int) bool {
+	return bits&(1<<n) != 0
+}
+
+func parseField(s string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		b, err := parseItem(item, f)
+		if err != nil {
+			return 0, err
+		}
+
+		bits |= b
+	}
+
+	return bits, nil
+}
+
+// parseItem parses a single item of a list in a field.
+func parseItem(s string, f field) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(s, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %q", stepPart)
+		}
+	}
+
+	var start, end int
+	switch low, high, isRange := strings.Cut(rangePart, "-"); {
+	case rangePart == "*":
+		start, end = f.min, f.max
+	case isRange:
+		var err error
+		if start, err = parseValue(low, f); err != nil {
+			return 0, err
+		}
+
+		if end, err = parseValue(high, f); err != nil {
+			return 0, err
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("range %q is in the wrong order", rangePart)
+		}
+	default:
+		var err error
+		if start, err = parseValue(rangePart, f); err != nil {
+			return 0, err
+		}
+
+		// A single value with a step, such as 5/15, runs from the value to the end of the field.
+		end = start
+		if hasStep {
+			end = f.max
+		}
+	}
+
+	var bits uint64
+	for n := start; n <= end; n += step {
+		bits |= 1 << n
+	}
+
+	return bits, nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d is outside %d-%d", n, f.min, f.max)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,118 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tts := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{"every minute", "* * * * *", false},
+		{"daily", "0 18 * * *", false},
+		{"weekdays", "30 8 * * 1-5", false},
+		{"steps and lists", "*/15 9,12,18 1-15/2 * *", false},
+		{"sunday as 7", "0 0 * * 7", false},
+		{"too few fields", "0 18 * *", true},
+		{"too many fields", "0 0 18 * * *", true},
+		{"value out of range", "60 * * * *", true},
+		{"day of month zero", "0 0 0 * *", true},
+		{"reversed range", "0 0 * * 5-1", true},
+		{"zero step", "*/0 * * * *", true},
+		{"not a number", "0 noon * * *", true},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	loc := time.FixedZone("SGT", 8*60*60)
+
+	tts := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			"every minute",
+			"* * * * *",
+			time.Date(2024, 1, 15, 10, 30, 45, 0, loc),
+			time.Date(2024, 1, 15, 10, 31, 0, 0, loc),
+		},
+		{
+			"later today",
+			"0 18 * * *",
+			time.Date(2024, 1, 15, 10, 30, 0, 0, loc),
+			time.Date(2024, 1, 15, 18, 0, 0, 0, loc),
+		},
+		{
+			"strictly after",
+			"0 18 * * *",
+			time.Date(2024, 1, 15, 18, 0, 0, 0, loc),
+			time.Date(2024, 1, 16, 18, 0, 0, 0, loc),
+		},
+		{
+			"next weekday",
+			"30 8 * * 1-5",
+			time.Date(2024, 1, 19, 9, 0, 0, 0, loc),
+			time.Date(2024, 1, 22, 8, 30, 0, 0, loc),
+		},
+		{
+			"step within hour",
+			"*/15 * * * *",
+			time.Date(2024, 1, 15, 10, 46, 0, 0, loc),
+			time.Date(2024, 1, 15, 11, 0, 0, 0, loc),
+		},
+		{
+			"sunday as 7",
+			"0 0 * * 7",
+			time.Date(2024, 1, 15, 0, 0, 0, 0, loc),
+			time.Date(2024, 1, 21, 0, 0, 0, 0, loc),
+		},
+		{
+			"day of month or day of week",
+			"0 0 1 * 3",
+			time.Date(2024, 1, 15, 0, 0, 0, 0, loc),
+			time.Date(2024, 1, 17, 0, 0, 0, 0, loc),
+		},
+		{
+			"next year",
+			"0 0 1 1 *",
+			time.Date(2024, 6, 1, 0, 0, 0, 0, loc),
+			time.Date(2025, 1, 1, 0, 0, 0, 0, loc),
+		},
+		{
+			"leap day",
+			"0 0 29 2 *",
+			time.Date(2024, 3, 1, 0, 0, 0, 0, loc),
+			time.Date(2028, 2, 29, 0, 0, 0, 0, loc),
+		},
+		{
+			"never",
+			"0 0 31 2 *",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, loc),
+			time.Time{},
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			s, err := Parse(tt.expr)
+			a.Nil(err)
+			a.True(tt.want.Equal(s.Next(tt.from)), "got %s", s.Next(tt.from))
+		})
+	}
+}